@@ -0,0 +1,55 @@
+package gomigrator
+
+func AlterTable(name string, tableColumns func(table *Blueprint), dialect SQLDialect) *Table {
+	return newTable(name, alterTable, tableColumns, dialect)
+}
+
+func parseAlterTemplate(t *Table) []string {
+	d := t.dialect()
+	features := d.Features()
+	statements := []string{}
+
+	for _, columns := range t.Blueprint.droppedIndexes {
+		statements = append(statements, d.DropIndex(t.Name, indexName(t.Name, columns, "_idx")))
+	}
+
+	if features.AlterConstraint {
+		for _, constraint := range t.droppedConstraints {
+			statements = append(statements, d.DropConstraint(t.Name, constraint.Name, constraint.Type))
+		}
+	}
+
+	for _, rename := range t.Blueprint.RenamedColumns {
+		statements = append(statements, d.RenameColumn(t.Name, rename.From, rename.To))
+	}
+
+	for _, column := range t.Blueprint.DroppedColumns {
+		statements = append(statements, d.DropColumn(t.Name, column))
+	}
+
+	for i := range t.Blueprint.Columns {
+		column := &t.Blueprint.Columns[i]
+
+		if !column.Property.Changed {
+			statements = append(statements, d.AddColumn(t.Name, column))
+			continue
+		}
+
+		// Without AlterColumn the column is changed by rebuilding the table,
+		// see Table.exec.
+		if features.AlterColumn {
+			statements = append(statements, d.ChangeColumn(t.Name, column)...)
+		}
+	}
+
+	if features.AlterConstraint {
+		for _, constraint := range t.TableConstraints {
+			statements = append(statements, alterTablePrefix(d, t.Name)+"ADD "+constraint)
+		}
+	}
+
+	// Without AlterConstraint the dropped constraints are left out of the
+	// rebuilt table, see Table.exec.
+
+	return statements
+}
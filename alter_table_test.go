@@ -0,0 +1,197 @@
+package gomigrator
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAlterTableAddColumn(t *testing.T) {
+	table := AlterTable("users", func(t *Blueprint) {
+		t.Varchar("nickname", 30, &TextColumnProps{Nullable: true})
+	}, MYSQL)
+
+	stmt := table.statements()[0]
+	expected := "ALTER TABLE `users` ADD COLUMN `nickname` varchar(30) NULL"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestAlterTableDropAndRenameColumn(t *testing.T) {
+	table := AlterTable("users", func(t *Blueprint) {
+		t.DropColumn("bio")
+		t.RenameColumn("first_name", "given_name")
+	}, POSTGRES)
+
+	statements := table.statements()
+	expected := []string{
+		`ALTER TABLE "users" RENAME COLUMN "first_name" TO "given_name"`,
+		`ALTER TABLE "users" DROP COLUMN "bio"`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestAlterTableChangeColumnMysql(t *testing.T) {
+	table := AlterTable("users", func(t *Blueprint) {
+		t.Varchar("first_name", 100, &TextColumnProps{Nullable: true}).Change()
+	}, MYSQL)
+
+	stmt := table.statements()[0]
+	expected := "ALTER TABLE `users` MODIFY COLUMN `first_name` varchar(100) NULL"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestAlterTableChangeColumnPostgres(t *testing.T) {
+	table := AlterTable("users", func(t *Blueprint) {
+		t.Int("grade", &NumericColumnProps{Nullable: true, Default: 1}).Change()
+	}, POSTGRES)
+
+	statements := table.statements()
+	expected := []string{
+		`ALTER TABLE "users" ALTER COLUMN "grade" TYPE int USING "grade"::int`,
+		`ALTER TABLE "users" ALTER COLUMN "grade" SET DEFAULT 1`,
+		`ALTER TABLE "users" ALTER COLUMN "grade" DROP NOT NULL`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestAlterTableChangeEnumPostgres(t *testing.T) {
+	table := AlterTable("users", func(t *Blueprint) {
+		t.Enum("role", []string{"admin", "member", "guest"}, &EnumColumnProps{Default: "guest"}).Change()
+	}, POSTGRES)
+
+	statements := table.statements()
+	expected := []string{
+		`CREATE TYPE "users_role_type_new" AS ENUM('admin', 'member', 'guest')`,
+		`ALTER TABLE "users" ALTER COLUMN "role" DROP DEFAULT`,
+		`ALTER TABLE "users" ALTER COLUMN "role" TYPE "users_role_type_new" USING "role"::text::"users_role_type_new"`,
+		`DROP TYPE IF EXISTS "users_role_type"`,
+		`ALTER TYPE "users_role_type_new" RENAME TO "users_role_type"`,
+		`ALTER TABLE "users" ALTER COLUMN "role" SET DEFAULT 'guest'`,
+		`ALTER TABLE "users" ALTER COLUMN "role" SET NOT NULL`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestAlterTableChangeIncrementPostgres(t *testing.T) {
+	table := AlterTable("users", func(t *Blueprint) {
+		t.BigIncrement("id").Change()
+	}, POSTGRES)
+
+	statements := table.statements()
+	expected := []string{
+		`ALTER TABLE "users" ALTER COLUMN "id" TYPE bigint USING "id"::bigint`,
+		`ALTER TABLE "users" ALTER COLUMN "id" SET NOT NULL`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestAlterTableDropSoftDeletesMysql(t *testing.T) {
+	table := AlterTable("posts", func(t *Blueprint) {
+		t.DropSoftDeletes()
+	}, MYSQL)
+
+	statements := table.statements()
+	expected := []string{
+		"DROP INDEX `posts_deleted_at_idx` ON `posts`",
+		"ALTER TABLE `posts` DROP COLUMN `deleted_at`",
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestAlterTableDropPrimary(t *testing.T) {
+	table := AlterTable("user_roles", func(t *Blueprint) {}, POSTGRES)
+	table.DropPrimary()
+	table.PrimaryKey("user_id", "role_id", "team_id")
+
+	statements := table.statements()
+	expected := []string{
+		`ALTER TABLE "user_roles" DROP CONSTRAINT "user_roles_pkey"`,
+		`ALTER TABLE "user_roles" ADD CONSTRAINT "user_roles_pkey" PRIMARY KEY ("user_id", "role_id", "team_id")`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+
+	table = AlterTable("user_roles", func(t *Blueprint) {}, MYSQL)
+	table.DropPrimary()
+
+	if stmt := table.statements()[0]; stmt != "ALTER TABLE `user_roles` DROP PRIMARY KEY" {
+		t.Errorf("Expected the MySQL primary key to be dropped, and got %q", stmt)
+	}
+}
+
+func TestAlterTableDropUnique(t *testing.T) {
+	table := AlterTable("memberships", func(t *Blueprint) {}, MYSQL)
+	table.DropUnique("memberships_team_user_unique")
+	table.Unique("memberships_team_user_role_unique", "team_id", "user_id", "role")
+
+	statements := table.statements()
+	expected := []string{
+		"ALTER TABLE `memberships` DROP INDEX `memberships_team_user_unique`",
+		"ALTER TABLE `memberships` ADD CONSTRAINT `memberships_team_user_role_unique` UNIQUE (`team_id`, `user_id`, `role`)",
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+
+	table = AlterTable("memberships", func(t *Blueprint) {}, POSTGRES)
+	table.DropUnique("memberships_team_user_unique")
+
+	if stmt := table.statements()[0]; stmt != `ALTER TABLE "memberships" DROP CONSTRAINT "memberships_team_user_unique"` {
+		t.Errorf("Expected the Postgres unique constraint to be dropped, and got %q", stmt)
+	}
+}
+
+func TestAlterTableDropCheck(t *testing.T) {
+	table := AlterTable("bookings", func(t *Blueprint) {}, MYSQL)
+	table.DropCheck("bookings_period_check")
+
+	if stmt := table.statements()[0]; stmt != "ALTER TABLE `bookings` DROP CHECK `bookings_period_check`" {
+		t.Errorf("Expected the MySQL check to be dropped, and got %q", stmt)
+	}
+
+	table = AlterTable("bookings", func(t *Blueprint) {}, POSTGRES)
+	table.DropCheck("bookings_period_check")
+	table.Check("bookings_period_check", "start_at <= end_at")
+
+	statements := table.statements()
+	expected := []string{
+		`ALTER TABLE "bookings" DROP CONSTRAINT "bookings_period_check"`,
+		`ALTER TABLE "bookings" ADD CONSTRAINT "bookings_period_check" CHECK (start_at <= end_at)`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestAlterTableDropForeign(t *testing.T) {
+	table := AlterTable("profiles", func(t *Blueprint) {}, MYSQL)
+	table.DropForeign("profiles_user_id_fkey")
+
+	if stmt := table.statements()[0]; stmt != "ALTER TABLE `profiles` DROP FOREIGN KEY `profiles_user_id_fkey`" {
+		t.Errorf("Expected the MySQL foreign key to be dropped, and got %q", stmt)
+	}
+}
@@ -1,18 +1,87 @@
 package gomigrator
 
+import "fmt"
+
+type ColumnRename struct {
+	From string
+	To   string
+}
+
 type Blueprint struct {
-	Columns []TableColumn
-	Dialect SQLDialect
+	Columns        []TableColumn
+	Dialect        SQLDialect
+	DroppedColumns []string
+	RenamedColumns []ColumnRename
+	indexes        [][]string
+	droppedIndexes [][]string
+	foreignIds     []*ForeignIdColumn
+	err            error
+}
+
+// Err returns the first invalid column definition, running the table
+// returns it as well.
+func (b *Blueprint) Err() error {
+	return b.err
+}
+
+func (b *Blueprint) features() DialectFeatures {
+	dialect, err := LookupDialect(b.Dialect)
+
+	if err != nil {
+		return DialectFeatures{}
+	}
+
+	return dialect.Features()
+}
+
+func (b *Blueprint) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
 }
 
-func (b *Blueprint) AddColumn(name string, props SQLTableProp) {
+// checkColumn fails the column when the dialect lacks its type, or stores an
+// array as JSON when it has a fallback.
+func (b *Blueprint) checkColumn(column *TableColumn) *TableColumn {
+	features := b.features()
+	prop := column.Property
+
+	switch {
+	case prop.Type == INTERVAL && !features.IntervalType:
+		b.fail(fmt.Errorf("column %s: dialect %s has no interval type", column.Name, b.Dialect))
+	case prop.Type == ARRAY && !features.ArrayType:
+		if !prop.jsonFallback {
+			b.fail(fmt.Errorf("column %s: dialect %s has no array type", column.Name, b.Dialect))
+		}
+
+		prop.Type = JSON
+	case prop.GeneratedAs != "" && !prop.Stored && !features.VirtualColumns:
+		b.fail(fmt.Errorf("column %s: dialect %s does not support virtual generated columns", column.Name, b.Dialect))
+	}
+
+	return column
+}
+
+func (b *Blueprint) AddColumn(name string, props SQLTableProp) *TableColumn {
 	b.Columns = append(b.Columns, TableColumn{
-		Name:     name,
-		Property: &props,
+		Name:      name,
+		Property:  &props,
+		dialect:   b.Dialect,
+		blueprint: b,
 	})
+
+	return &b.Columns[len(b.Columns)-1]
+}
+
+func (b *Blueprint) DropColumn(names ...string) {
+	b.DroppedColumns = append(b.DroppedColumns, names...)
+}
+
+func (b *Blueprint) RenameColumn(from string, to string) {
+	b.RenamedColumns = append(b.RenamedColumns, ColumnRename{From: from, To: to})
 }
 
-func (b *Blueprint) Varchar(name string, length int, props *TextColumnProps) {
+func (b *Blueprint) Varchar(name string, length int, props *TextColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: VARCHAR,
 		Size: length,
@@ -22,10 +91,10 @@ func (b *Blueprint) Varchar(name string, length int, props *TextColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Char(name string, length int, props *TextColumnProps) {
+func (b *Blueprint) Char(name string, length int, props *TextColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: CHAR,
 		Size: length,
@@ -35,10 +104,10 @@ func (b *Blueprint) Char(name string, length int, props *TextColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Text(name string, props *TextColumnProps) {
+func (b *Blueprint) Text(name string, props *TextColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: TEXT,
 	}
@@ -47,10 +116,10 @@ func (b *Blueprint) Text(name string, props *TextColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Date(name string, props *TextColumnProps) {
+func (b *Blueprint) Date(name string, props *TextColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: DATE,
 	}
@@ -59,10 +128,10 @@ func (b *Blueprint) Date(name string, props *TextColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Timestamp(name string, props *TextColumnProps) {
+func (b *Blueprint) Timestamp(name string, props *TextColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: TIMESTAMP,
 	}
@@ -71,10 +140,10 @@ func (b *Blueprint) Timestamp(name string, props *TextColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) DateTime(name string, props *TextColumnProps) {
+func (b *Blueprint) DateTime(name string, props *TextColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: DATETIME,
 	}
@@ -83,10 +152,64 @@ func (b *Blueprint) DateTime(name string, props *TextColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
+}
+
+// TimestampTz stores a point in time with its time zone. MySQL and SQLite
+// have no such type and use timestamp.
+func (b *Blueprint) TimestampTz(name string, props *TextColumnProps) *TableColumn {
+	dataType := SQLTableProp{
+		Type: TIMESTAMPTZ,
+	}
+
+	if props != nil {
+		fillProps(&dataType, props)
+	}
+
+	return b.AddColumn(name, dataType)
+}
+
+func (b *Blueprint) Time(name string, props *TextColumnProps) *TableColumn {
+	dataType := SQLTableProp{
+		Type: TIME,
+	}
+
+	if props != nil {
+		fillProps(&dataType, props)
+	}
+
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Enum(name string, options []string, props *EnumColumnProps) {
+func (b *Blueprint) Interval(name string, props *TextColumnProps) *TableColumn {
+	dataType := SQLTableProp{
+		Type: INTERVAL,
+	}
+
+	if props != nil {
+		fillProps(&dataType, props)
+	}
+
+	return b.checkColumn(b.AddColumn(name, dataType))
+}
+
+// Array declares a Postgres array of elementType. Other dialects fail unless
+// props.JSONFallback stores the array as JSON instead.
+func (b *Blueprint) Array(name string, elementType SQLDataType, props *ArrayColumnProps) *TableColumn {
+	dataType := SQLTableProp{
+		Type:        ARRAY,
+		ElementType: elementType,
+	}
+
+	if props != nil {
+		fillProps(&dataType, props)
+		dataType.jsonFallback = props.JSONFallback
+	}
+
+	return b.checkColumn(b.AddColumn(name, dataType))
+}
+
+func (b *Blueprint) Enum(name string, options []string, props *EnumColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type:        ENUM,
 		EnumOptions: options,
@@ -96,10 +219,10 @@ func (b *Blueprint) Enum(name string, options []string, props *EnumColumnProps)
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Int(name string, props *NumericColumnProps) {
+func (b *Blueprint) Int(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: INT,
 	}
@@ -108,27 +231,27 @@ func (b *Blueprint) Int(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Serial(name string) {
+func (b *Blueprint) Serial(name string) *TableColumn {
 	dataType := SQLTableProp{
 		Type: SERIAL,
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) BigSerial(name string) {
+func (b *Blueprint) BigSerial(name string) *TableColumn {
 	dataType := SQLTableProp{
 		Type:     BIGSERIAL,
 		Unsigned: true,
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Tinyint(name string, props *NumericColumnProps) {
+func (b *Blueprint) Tinyint(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: TINYINT,
 	}
@@ -137,13 +260,10 @@ func (b *Blueprint) Tinyint(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.Columns = append(b.Columns, TableColumn{
-		Name:     name,
-		Property: &dataType,
-	})
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Mediumint(name string, props *NumericColumnProps) {
+func (b *Blueprint) Mediumint(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: MEDIUMINT,
 	}
@@ -152,13 +272,10 @@ func (b *Blueprint) Mediumint(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.Columns = append(b.Columns, TableColumn{
-		Name:     name,
-		Property: &dataType,
-	})
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Bigint(name string, props *NumericColumnProps) {
+func (b *Blueprint) Bigint(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: BIGINT,
 	}
@@ -167,13 +284,10 @@ func (b *Blueprint) Bigint(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.Columns = append(b.Columns, TableColumn{
-		Name:     name,
-		Property: &dataType,
-	})
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Boolean(name string, props *NumericColumnProps) {
+func (b *Blueprint) Boolean(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: BOOL,
 	}
@@ -182,10 +296,10 @@ func (b *Blueprint) Boolean(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Float(name string, props *NumericColumnProps) {
+func (b *Blueprint) Float(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: FLOAT,
 	}
@@ -194,10 +308,10 @@ func (b *Blueprint) Float(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Double(name string, props *NumericColumnProps) {
+func (b *Blueprint) Double(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: DOUBLE,
 	}
@@ -206,10 +320,10 @@ func (b *Blueprint) Double(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Real(name string, props *NumericColumnProps) {
+func (b *Blueprint) Real(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: REAL,
 	}
@@ -218,10 +332,10 @@ func (b *Blueprint) Real(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) DoublePrecision(name string, props *NumericColumnProps) {
+func (b *Blueprint) DoublePrecision(name string, props *NumericColumnProps) *TableColumn {
 	dataType := SQLTableProp{
 		Type: DOUBLE_PRECISION,
 	}
@@ -230,54 +344,145 @@ func (b *Blueprint) DoublePrecision(name string, props *NumericColumnProps) {
 		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Increment(name string) {
+func (b *Blueprint) Json(name string, props *JSONColumnProps) *TableColumn {
 	dataType := SQLTableProp{
-		Type:       SERIAL,
-		PrimaryKey: true,
+		Type: JSON,
 	}
 
-	if b.Dialect == MYSQL {
-		dataType.Type = INT
-		dataType.PrimaryKey = false
-		dataType.AutoIncrement = true
+	if props != nil {
+		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) BigIncrement(name string) {
+// Jsonb stores the JSON in the binary format of Postgres, other dialects use
+// their JSON type.
+func (b *Blueprint) Jsonb(name string, props *JSONColumnProps) *TableColumn {
 	dataType := SQLTableProp{
-		Type:       BIGSERIAL,
-		PrimaryKey: true,
+		Type: JSONB,
 	}
 
-	if b.Dialect == MYSQL {
-		dataType.Type = BIGINT
-		dataType.PrimaryKey = false
-		dataType.AutoIncrement = true
+	if props != nil {
+		fillProps(&dataType, props)
 	}
 
-	b.AddColumn(name, dataType)
+	return b.AddColumn(name, dataType)
 }
 
-func (b *Blueprint) Uuid(name string, props *UUIDColumnProps) {
+// Binary declares a column of up to length bytes, or exactly length bytes
+// when props.Fixed is set.
+func (b *Blueprint) Binary(name string, length int, props *BinaryColumnProps) *TableColumn {
 	dataType := SQLTableProp{
-		Type:    UUID,
-		Default: "gen_random_uuid()",
+		Type: VARBINARY,
 	}
 
 	if props != nil {
 		fillProps(&dataType, props)
+
+		if props.Fixed {
+			dataType.Type = BINARY
+		}
+	}
+
+	dataType.Size = length
+
+	if length < 1 {
+		b.fail(fmt.Errorf("column %s: invalid binary length %d", name, length))
 	}
 
-	if b.Dialect == MYSQL {
-		dataType.Type = VARCHAR
-		dataType.Default = "uuid()"
-		dataType.Size = 36
+	return b.AddColumn(name, dataType)
+}
+
+// Blob declares a column for large binary payloads, MySQL picks the smallest
+// blob type holding props.Size bytes.
+func (b *Blueprint) Blob(name string, props *BinaryColumnProps) *TableColumn {
+	dataType := SQLTableProp{
+		Type: BLOB,
 	}
 
-	b.AddColumn(name, dataType)
+	if props != nil {
+		fillProps(&dataType, props)
+	}
+
+	return b.AddColumn(name, dataType)
+}
+
+// Decimal declares an exact numeric column with precision total digits, scale
+// of them after the decimal point.
+func (b *Blueprint) Decimal(name string, precision int, scale int, props *NumericColumnProps) *TableColumn {
+	dataType := SQLTableProp{
+		Type: DECIMAL,
+	}
+
+	if props != nil {
+		fillProps(&dataType, props)
+	}
+
+	dataType.Precision = precision
+	dataType.Scale = scale
+
+	if precision < 1 || scale < 0 || scale > precision {
+		b.fail(fmt.Errorf("column %s: invalid decimal precision %d and scale %d", name, precision, scale))
+	}
+
+	return b.AddColumn(name, dataType)
+}
+
+func (b *Blueprint) Increment(name string) *TableColumn {
+	dataType := SQLTableProp{
+		Type:          INT,
+		AutoIncrement: true,
+	}
+
+	return b.AddColumn(name, dataType)
+}
+
+func (b *Blueprint) BigIncrement(name string) *TableColumn {
+	dataType := SQLTableProp{
+		Type:          BIGINT,
+		AutoIncrement: true,
+	}
+
+	return b.AddColumn(name, dataType)
+}
+
+func (b *Blueprint) Uuid(name string, props *UUIDColumnProps) *TableColumn {
+	dataType := SQLTableProp{
+		Type:    UUID,
+		Default: generatedUUID{},
+	}
+
+	if props != nil {
+		fillProps(&dataType, props)
+	}
+
+	return b.AddColumn(name, dataType)
+}
+
+// Timestamps adds created_at and updated_at columns set to the current time,
+// MySQL updates updated_at on every change of the row.
+func (b *Blueprint) Timestamps() {
+	b.Timestamp("created_at", &TextColumnProps{Default: Raw("CURRENT_TIMESTAMP")})
+	b.Timestamp("updated_at", &TextColumnProps{Default: Raw("CURRENT_TIMESTAMP")}).UseCurrentOnUpdate()
+}
+
+func (b *Blueprint) DropTimestamps() {
+	b.DropColumn("created_at", "updated_at")
+}
+
+// SoftDeletes adds the nullable deleted_at column marking deleted rows,
+// indexed as most queries filter on it.
+func (b *Blueprint) SoftDeletes() *TableColumn {
+	b.indexes = append(b.indexes, []string{"deleted_at"})
+
+	return b.Timestamp("deleted_at", &TextColumnProps{Nullable: true})
+}
+
+func (b *Blueprint) DropSoftDeletes() {
+	b.droppedIndexes = append(b.droppedIndexes, []string{"deleted_at"})
+	b.DropColumn("deleted_at")
 }
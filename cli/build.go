@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// buildEnv marks the command built by runMigrationsPackage, so that it does
+// not build itself again when the package registers no migrations.
+const buildEnv = "GO_MIGRATOR_BUILT"
+
+// runMigrationsPackage builds a command importing the migrations package in
+// dir and runs it with the same arguments, as a go-migrator binary installed
+// on its own has no migrations compiled in.
+func runMigrationsPackage(dir string, args []string, stdout io.Writer, stderr io.Writer) error {
+	if os.Getenv(buildEnv) != "" {
+		return fmt.Errorf("the migrations package in %s registers no migrations", dir)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("no migrations are compiled into go-migrator and %s has no migration files, create one with make:migration", dir)
+	}
+
+	root, importPath, err := migrationsPackage(dir)
+
+	if err != nil {
+		return err
+	}
+
+	// The main package has to be inside the module to import the migrations,
+	// the leading underscore keeps it out of ./... patterns.
+	source, err := os.MkdirTemp(root, "_go-migrator-")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(source)
+
+	output, err := os.MkdirTemp("", "go-migrator-")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(output)
+
+	file, err := os.Create(filepath.Join(source, "main.go"))
+
+	if err != nil {
+		return err
+	}
+
+	err = mainTemplate.Execute(file, importPath)
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	binary := filepath.Join(output, "go-migrator")
+	build := exec.Command("go", "build", "-o", binary, "./"+filepath.Base(source))
+	build.Dir = root
+	build.Stdout = stderr
+	build.Stderr = stderr
+
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("no migrations are compiled into go-migrator and building %s failed: %w", importPath, err)
+	}
+
+	cmd := exec.Command(binary, args...)
+	cmd.Env = append(os.Environ(), buildEnv+"=1")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	return cmd.Run()
+}
+
+// migrationsPackage returns the root of the module containing dir and the
+// import path of dir.
+func migrationsPackage(dir string) (string, string, error) {
+	abs, err := filepath.Abs(dir)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	for root := abs; ; root = filepath.Dir(root) {
+		module, err := modulePath(filepath.Join(root, "go.mod"))
+
+		if err == nil {
+			rel, err := filepath.Rel(root, abs)
+
+			if err != nil || rel == "." {
+				return root, module, err
+			}
+
+			return root, module + "/" + filepath.ToSlash(rel), nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", "", err
+		}
+
+		if filepath.Dir(root) == root {
+			return "", "", fmt.Errorf("%s is not inside a Go module", dir)
+		}
+	}
+}
+
+func modulePath(goMod string) (string, error) {
+	file, err := os.Open(goMod)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("%s declares no module", goMod)
+}
+
+var mainTemplate = template.Must(template.New("main").Parse(`package main
+
+import (
+	"os"
+
+	"github.com/suryaherdiyanto/go-migrator/cli"
+
+	_ "{{.}}"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
+`))
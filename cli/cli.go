@@ -0,0 +1,385 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	gomigrator "github.com/suryaherdiyanto/go-migrator"
+)
+
+const usage = `Usage: go-migrator [flags] <command> [arguments]
+
+Commands:
+  migrate                 Run all pending migrations
+  rollback [-step N]      Revert the last batch, or the last N migrations
+  reset                   Revert every applied migration
+  refresh                 Reset and run all migrations again
+  status                  Show which migrations have been applied
+  make:migration <name>   Scaffold a new migration file
+
+Flags:
+`
+
+var migrationName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var createTableName = regexp.MustCompile(`^create_(\w+?)_table$`)
+
+var alterTableName = regexp.MustCompile(`_(?:to|from|in)_(\w+?)(?:_table)?$`)
+
+var migrationFile = regexp.MustCompile(`^(\d{14})_\w+\.go$`)
+
+type config struct {
+	driver  string
+	dsn     string
+	dir     string
+	table   string
+	pretend bool
+	args    []string
+}
+
+// Run executes the command line arguments against the migrations registered
+// with gomigrator.Register and returns the process exit code. Without any, it
+// builds and runs a command including the package in the migrations directory.
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	conf := config{args: args}
+
+	flags := flag.NewFlagSet("go-migrator", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&conf.driver, "driver", envOr("DB_DRIVER", "mysql"), "database driver: mysql, postgres, sqlite3 or sqlserver (env DB_DRIVER)")
+	flags.StringVar(&conf.dsn, "dsn", os.Getenv("DATABASE_URL"), "data source name (env DATABASE_URL)")
+	flags.StringVar(&conf.dir, "dir", envOr("MIGRATIONS_DIR", "migrations"), "directory of the migration files (env MIGRATIONS_DIR)")
+	flags.StringVar(&conf.table, "table", gomigrator.DefaultMigrationTable, "name of the migrations tracking table")
+	flags.BoolVar(&conf.pretend, "pretend", false, "print the SQL of migrate and rollback commands instead of executing it")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	if err := run(conf, flags.Arg(0), flags.Args()[1:], stdout, stderr); err != nil {
+		// The command built by runMigrationsPackage reported its error already.
+		var exit *exec.ExitError
+
+		if errors.As(err, &exit) {
+			return exit.ExitCode()
+		}
+
+		fmt.Fprintln(stderr, "go-migrator:", err)
+		return 1
+	}
+
+	return 0
+}
+
+func run(conf config, command string, args []string, stdout io.Writer, stderr io.Writer) error {
+	if command == "make:migration" {
+		if len(args) != 1 {
+			return errors.New("make:migration expects exactly one name")
+		}
+
+		path, err := makeMigration(conf.dir, args[0], time.Now().UTC())
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, "Created migration", path)
+		return nil
+	}
+
+	if conf.dsn == "" {
+		return errors.New("missing data source name, set -dsn or DATABASE_URL")
+	}
+
+	dialect := gomigrator.SQLDialect(conf.driver)
+
+	if _, err := gomigrator.LookupDialect(dialect); err != nil {
+		return err
+	}
+
+	if len(gomigrator.RegisteredMigrations()) == 0 {
+		return runMigrationsPackage(conf.dir, conf.args, stdout, stderr)
+	}
+
+	db, err := gomigrator.NewConnection(conf.driver, conf.dsn)
+
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	migrator := gomigrator.NewMigrator(db, dialect)
+	migrator.TableName = conf.table
+	migrator.Pretend = conf.pretend
+	migrator.Output = stdout
+
+	if err := migrator.Register(gomigrator.RegisteredMigrations()...); err != nil {
+		return err
+	}
+
+	switch command {
+	case "migrate":
+		return migrate(migrator, stdout)
+	case "rollback":
+		flags := flag.NewFlagSet("rollback", flag.ContinueOnError)
+		step := flags.Int("step", 0, "number of migrations to revert, the last batch when zero")
+
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+
+		return report(migrator, stdout, "Rolled back", func() error {
+			return migrator.Rollback(*step)
+		})
+	case "reset":
+		return report(migrator, stdout, "Rolled back", migrator.Reset)
+	case "refresh":
+		if err := migrator.Reset(); err != nil {
+			return err
+		}
+
+		return migrate(migrator, stdout)
+	case "status":
+		return status(migrator, stdout)
+	}
+
+	return fmt.Errorf("unknown command %q", command)
+}
+
+func migrate(migrator *gomigrator.Migrator, stdout io.Writer) error {
+	pending, err := migrator.Pending()
+
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		fmt.Fprintln(stdout, "Nothing to migrate")
+		return nil
+	}
+
+	if err := migrator.Migrate(); err != nil || migrator.Pretend {
+		return err
+	}
+
+	for _, migration := range pending {
+		fmt.Fprintln(stdout, "Migrated", migration)
+	}
+
+	return nil
+}
+
+// report runs fn and prints the migrations that are no longer applied
+// afterwards.
+func report(migrator *gomigrator.Migrator, stdout io.Writer, verb string, fn func() error) error {
+	before, err := migrator.Applied()
+
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	after, appliedErr := migrator.Applied()
+
+	if appliedErr != nil {
+		return errors.Join(err, appliedErr)
+	}
+
+	slices.Reverse(before)
+	for _, record := range before {
+		stillApplied := slices.ContainsFunc(after, func(a gomigrator.AppliedMigration) bool {
+			return a.Version == record.Version
+		})
+
+		if !stillApplied {
+			fmt.Fprintf(stdout, "%s %d_%s\n", verb, record.Version, record.Name)
+		}
+	}
+
+	return err
+}
+
+func status(migrator *gomigrator.Migrator, stdout io.Writer) error {
+	applied, err := migrator.Applied()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%-5s %-5s %s\n", "Ran?", "Batch", "Migration")
+
+	for _, migration := range migrator.Migrations() {
+		ran, batch := "No", ""
+		index := slices.IndexFunc(applied, func(a gomigrator.AppliedMigration) bool {
+			return a.Version == migration.Version
+		})
+
+		if index >= 0 {
+			ran, batch = "Yes", fmt.Sprint(applied[index].Batch)
+		}
+
+		fmt.Fprintf(stdout, "%-5s %-5s %s\n", ran, batch, migration)
+	}
+
+	return nil
+}
+
+func makeMigration(dir string, name string, now time.Time) (string, error) {
+	if !migrationName.MatchString(name) {
+		return "", fmt.Errorf("invalid migration name %q, use snake_case like create_users_table", name)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	version, err := nextVersion(dir, now)
+
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, version+"_"+name+".go")
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	data := migrationTemplateData{
+		Package: packageName(dir),
+		Version: version,
+		Name:    name,
+		Table:   "table_name",
+	}
+
+	if match := createTableName.FindStringSubmatch(name); match != nil {
+		data.Table = match[1]
+		data.Create = true
+	} else if match := alterTableName.FindStringSubmatch(name); match != nil {
+		data.Table = match[1]
+	}
+
+	return path, migrationTemplate.Execute(file, data)
+}
+
+// nextVersion uses the current time as version, bumped past the newest
+// migration in dir so that files created within the same second stay unique.
+func nextVersion(dir string, now time.Time) (string, error) {
+	version := now.Format("20060102150405")
+
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+
+		if match == nil || match[1] < version {
+			continue
+		}
+
+		latest, err := strconv.ParseInt(match[1], 10, 64)
+
+		if err != nil {
+			return "", err
+		}
+
+		version = strconv.FormatInt(latest+1, 10)
+	}
+
+	return version, nil
+}
+
+func packageName(dir string) string {
+	abs, err := filepath.Abs(dir)
+
+	if err != nil {
+		return "migrations"
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+
+		return r
+	}, strings.ToLower(filepath.Base(abs)))
+
+	if !migrationName.MatchString(name) {
+		return "migrations"
+	}
+
+	return name
+}
+
+func envOr(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+type migrationTemplateData struct {
+	Package string
+	Version string
+	Name    string
+	Table   string
+	Create  bool
+}
+
+var migrationTemplate = template.Must(template.New("migration").Parse(`package {{.Package}}
+
+import gomigrator "github.com/suryaherdiyanto/go-migrator"
+
+func init() {
+	gomigrator.Register(gomigrator.Migration{
+		Version: {{.Version}},
+		Name:    "{{.Name}}",
+		Up: func(s *gomigrator.Schema) {
+{{- if .Create}}
+			s.Create("{{.Table}}", func(t *gomigrator.Blueprint) {
+				t.Increment("id")
+			})
+{{- else}}
+			s.Alter("{{.Table}}", func(t *gomigrator.Blueprint) {
+			})
+{{- end}}
+		},
+		Down: func(s *gomigrator.Schema) {
+{{- if .Create}}
+			s.DropIfExists("{{.Table}}")
+{{- else}}
+			s.Alter("{{.Table}}", func(t *gomigrator.Blueprint) {
+			})
+{{- end}}
+		},
+	})
+}
+`))
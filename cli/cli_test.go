@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMakeMigration(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	path, err := makeMigration(dir, "create_users_table", now)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := filepath.Join(dir, "20240102030405_create_users_table.go")
+
+	if path != expected {
+		t.Errorf("Expected: %s, and got %q", expected, path)
+	}
+
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), path, content, 0); err != nil {
+		t.Errorf("Expected the scaffolded migration to be valid Go: %v", err)
+	}
+
+	if !strings.Contains(string(content), `s.Create("users"`) {
+		t.Errorf("Expected the migration to create the users table, and got %s", content)
+	}
+}
+
+func TestMakeMigrationInvalidName(t *testing.T) {
+	if _, err := makeMigration(t.TempDir(), "Create Users", time.Now()); err == nil {
+		t.Error("Expected an invalid migration name to be rejected")
+	}
+}
+
+func TestRunWithoutDSN(t *testing.T) {
+	t.Setenv("DATABASE_URL", "")
+
+	var stdout, stderr bytes.Buffer
+
+	if code := Run([]string{"migrate"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1, and got %d", code)
+	}
+
+	if !strings.Contains(stderr.String(), "missing data source name") {
+		t.Errorf("Expected a missing dsn error, and got %q", stderr.String())
+	}
+}
+
+func TestMakeMigrationWithinTheSameSecond(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if _, err := makeMigration(dir, "create_users_table", now); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := makeMigration(dir, "add_bio_to_users", now)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := filepath.Join(dir, "20240102030406_add_bio_to_users.go")
+
+	if path != expected {
+		t.Errorf("Expected: %s, and got %q", expected, path)
+	}
+}
+
+func TestRunUnknownDriver(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := Run([]string{"-driver", "oracle", "-dsn", "x", "status"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1, and got %d", code)
+	}
+
+	if !strings.Contains(stderr.String(), `unknown dialect "oracle"`) {
+		t.Errorf("Expected an unknown dialect error, and got %q", stderr.String())
+	}
+}
+
+func TestRunWithoutMigrations(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := Run([]string{"-driver", "sqlite3", "-dsn", ":memory:", "-dir", t.TempDir(), "status"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1, and got %d", code)
+	}
+
+	if !strings.Contains(stderr.String(), "no migrations are compiled into go-migrator") {
+		t.Errorf("Expected a missing migrations error, and got %q", stderr.String())
+	}
+}
+
+func TestMigrationsPackage(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "db", "migrations")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/service\n\ngo 1.22\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	moduleRoot, importPath, err := migrationsPackage(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if moduleRoot != root || importPath != "example.com/service/db/migrations" {
+		t.Errorf("Expected the package example.com/service/db/migrations in %s, and got %q in %s", root, importPath, moduleRoot)
+	}
+}
@@ -0,0 +1,287 @@
+package gomigrator
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dialect renders the statements of a table for one database. The built-in
+// dialects are registered under POSTGRES, MYSQL, SQLITE and MSSQL, other
+// databases can be added with RegisterDialect.
+type Dialect interface {
+	// Name is the SQLDialect the dialect is registered under.
+	Name() SQLDialect
+	Features() DialectFeatures
+	QuoteIdent(name string) string
+	// QuoteLiteral renders value as an escaped string literal.
+	QuoteLiteral(value string) string
+	// Literal renders a Go value, like a column default, as a typed SQL
+	// literal. Raw values are returned verbatim, nil renders NULL, byte
+	// slices are hex encoded and maps, slices and structs are encoded as JSON.
+	Literal(value interface{}) string
+	// Placeholder returns the bind parameter for the nth (1-based) argument.
+	Placeholder(n int) string
+	// ColumnType maps the type of a column property to the database type.
+	ColumnType(prop *SQLTableProp) string
+	// ColumnDefinition renders a column as used by CREATE TABLE and ADD COLUMN.
+	ColumnDefinition(table string, column *TableColumn) string
+	// TypeStatements returns the statements that have to run before the
+	// column is created or changed, like CREATE TYPE for Postgres enums.
+	TypeStatements(table string, column *TableColumn) []string
+	CreateTable(table string, definitions []string) string
+	AddColumn(table string, column *TableColumn) string
+	// ChangeColumn is only called when Features().AlterColumn is set.
+	ChangeColumn(table string, column *TableColumn) []string
+	RenameColumn(table string, from string, to string) string
+	DropColumn(table string, column string) string
+	DropIndex(table string, index string) string
+	// DropConstraint is only called when Features().AlterConstraint is set.
+	DropConstraint(table string, name string, constraintType ConstraintType) string
+	DropTable(table string, ifExists bool, cascade bool) []string
+	RenameTable(from string, to string) []string
+	// TableExistsQuery counts the tables named by its single argument.
+	TableExistsQuery() string
+}
+
+type DialectFeatures struct {
+	// TransactionalDDL runs the statements of a table or migration inside a
+	// transaction.
+	TransactionalDDL bool
+	// AlterColumn allows changing a column in place. Without it a changed
+	// column requires a table rebuild, which only SQLite implements.
+	AlterColumn bool
+	// AlterConstraint allows adding table constraints, like foreign keys, to
+	// an existing table.
+	AlterConstraint bool
+	// GinIndex allows Table.CreateGinIndex.
+	GinIndex bool
+	// IntervalType allows Interval columns.
+	IntervalType bool
+	// ArrayType allows Array columns.
+	ArrayType bool
+	// UniqueOptions allows NULLS NOT DISTINCT and INCLUDE on unique
+	// constraints.
+	UniqueOptions bool
+	// DeferrableConstraints allows foreign keys checked at commit.
+	DeferrableConstraints bool
+	// ConstraintValidation allows NOT VALID foreign keys and
+	// Table.ValidateForeign.
+	ConstraintValidation bool
+	// VirtualColumns allows generated columns that are not stored.
+	VirtualColumns bool
+	// ReferentialActions are the ON DELETE and ON UPDATE actions foreign keys
+	// accept, like ActionCascade.
+	ReferentialActions []string
+}
+
+// TableRebuilder is implemented by dialects without AlterColumn or
+// AlterConstraint, that apply column changes and constraints by recreating
+// the table.
+type TableRebuilder interface {
+	// PrepareRebuild runs on the connection before its transaction starts,
+	// restore runs once the transaction ended.
+	PrepareRebuild(conn *sql.Conn) (restore func() error, err error)
+	// RebuildTable applies the changed columns of t.Blueprint, its
+	// TableConstraints and DroppedConstraints.
+	RebuildTable(db Execer, t *Table) error
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[SQLDialect]Dialect{
+		POSTGRES: postgresDialect{},
+		MYSQL:    mysqlDialect{},
+		SQLITE:   sqliteDialect{},
+		MSSQL:    mssqlDialect{},
+	}
+)
+
+// RegisterDialect makes a dialect available under its name. It panics when
+// the dialect is nil or the name is already registered.
+func RegisterDialect(dialect Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	if dialect == nil {
+		panic("gomigrator: RegisterDialect dialect is nil")
+	}
+
+	if _, exists := dialects[dialect.Name()]; exists {
+		panic("gomigrator: RegisterDialect called twice for dialect " + string(dialect.Name()))
+	}
+
+	dialects[dialect.Name()] = dialect
+}
+
+func LookupDialect(name SQLDialect) (Dialect, error) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	dialect, ok := dialects[name]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown dialect %q", name)
+	}
+
+	return dialect, nil
+}
+
+// dialectOf panics for an unregistered dialect, as building a table for one
+// is a programming error.
+func dialectOf(name SQLDialect) Dialect {
+	dialect, err := LookupDialect(name)
+
+	if err != nil {
+		panic("gomigrator: " + err.Error())
+	}
+
+	return dialect
+}
+
+// generatedUUID is the default of Uuid columns, each dialect renders its own
+// UUID generating function.
+type generatedUUID struct{}
+
+// literalFormat holds the literals that differ between the built-in dialects.
+type literalFormat struct {
+	True  string
+	False string
+	Time  string
+	// Bytes formats the hex encoded value of a byte slice.
+	Bytes string
+}
+
+func formatLiteral(d Dialect, value interface{}, format literalFormat) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case Raw:
+		return string(v)
+	case time.Time:
+		return d.QuoteLiteral(v.Format(format.Time))
+	case []byte:
+		return fmt.Sprintf(format.Bytes, v)
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "NULL"
+		}
+
+		return formatLiteral(d, v.Elem().Interface(), format)
+	case reflect.Bool:
+		if v.Bool() {
+			return format.True
+		}
+
+		return format.False
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.String:
+		return d.QuoteLiteral(v.String())
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		if encoded, err := json.Marshal(value); err == nil {
+			return d.QuoteLiteral(string(encoded))
+		}
+	}
+
+	return d.QuoteLiteral(fmt.Sprint(value))
+}
+
+func quoteLiterals(dialect Dialect, values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = dialect.QuoteLiteral(value)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func quoteIdents(dialect Dialect, names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = dialect.QuoteIdent(name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// columnModifiers renders the constraints every built-in dialect shares, in
+// the order the column parser always emitted them. Columns are NOT NULL
+// unless they are Nullable, primary keys already imply it.
+func columnModifiers(prop *SQLTableProp, defaultValue string) string {
+	stmt := ""
+
+	if prop.Unique {
+		stmt += " UNIQUE"
+	}
+
+	if prop.PrimaryKey {
+		stmt += " PRIMARY KEY"
+	}
+
+	if prop.Nullable {
+		stmt += " NULL"
+	} else if !prop.PrimaryKey && !prop.AutoIncrement {
+		stmt += " NOT NULL"
+	}
+
+	if defaultValue != "" && prop.GeneratedAs == "" {
+		stmt += " DEFAULT " + defaultValue
+	}
+
+	if prop.Check != "" {
+		stmt += " CHECK (" + prop.Check + ")"
+	}
+
+	return stmt
+}
+
+// generatedColumn renders the clause computing a generated column, keyword
+// being the dialect's syntax up to the expression.
+func generatedColumn(prop *SQLTableProp, keyword string) string {
+	if prop.GeneratedAs == "" {
+		return ""
+	}
+
+	if prop.Stored {
+		return " " + keyword + " (" + prop.GeneratedAs + ") STORED"
+	}
+
+	return " " + keyword + " (" + prop.GeneratedAs + ") VIRTUAL"
+}
+
+func standardQuoteLiteral(value string) string {
+	return "'" + escapeLiteral(value) + "'"
+}
+
+func standardCreateTable(d Dialect, table string, definitions []string) string {
+	return "CREATE TABLE IF NOT EXISTS " + d.QuoteIdent(table) + "(" + strings.Join(definitions, ",") + ")"
+}
+
+func standardDropTable(d Dialect, table string, ifExists bool) string {
+	stmt := "DROP TABLE "
+
+	if ifExists {
+		stmt += "IF EXISTS "
+	}
+
+	return stmt + d.QuoteIdent(table)
+}
+
+func alterTablePrefix(d Dialect, table string) string {
+	return "ALTER TABLE " + d.QuoteIdent(table) + " "
+}
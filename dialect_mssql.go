@@ -0,0 +1,197 @@
+package gomigrator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type mssqlDialect struct{}
+
+func (mssqlDialect) Name() SQLDialect {
+	return MSSQL
+}
+
+func (mssqlDialect) Features() DialectFeatures {
+	return DialectFeatures{
+		TransactionalDDL:   true,
+		AlterColumn:        true,
+		AlterConstraint:    true,
+		VirtualColumns:     true,
+		ReferentialActions: []string{ActionCascade, ActionSetNull, ActionNoAction, ActionSetDefault},
+	}
+}
+
+func (mssqlDialect) QuoteIdent(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+func (mssqlDialect) QuoteLiteral(value string) string {
+	return standardQuoteLiteral(value)
+}
+
+func (d mssqlDialect) Literal(value interface{}) string {
+	return formatLiteral(d, value, literalFormat{True: "1", False: "0", Time: "2006-01-02T15:04:05.9999999", Bytes: "0x%X"})
+}
+
+func (mssqlDialect) Placeholder(n int) string {
+	return "@p" + strconv.Itoa(n)
+}
+
+func (mssqlDialect) ColumnType(prop *SQLTableProp) string {
+	switch prop.Type {
+	case VARCHAR, CHAR:
+		if prop.Size > 0 {
+			return fmt.Sprintf("n%s(%d)", prop.Type, prop.Size)
+		}
+
+		return "n" + string(prop.Type) + "(max)"
+	case TEXT, JSON, JSONB:
+		return "nvarchar(max)"
+	case ENUM:
+		return "nvarchar(255)"
+	case BOOL:
+		return "bit"
+	case MEDIUMINT, SERIAL:
+		return "int"
+	case BIGSERIAL:
+		return "bigint"
+	case DOUBLE, DOUBLE_PRECISION:
+		return "float(53)"
+	case DATETIME, TIMESTAMP:
+		return temporalType("datetime2", prop.Precision)
+	case TIMESTAMPTZ:
+		return temporalType("datetimeoffset", prop.Precision)
+	case UUID:
+		return "uniqueidentifier"
+	case VARBINARY:
+		if prop.Size > 0 && prop.Size <= 8000 {
+			return fmt.Sprintf("varbinary(%d)", prop.Size)
+		}
+
+		return "varbinary(max)"
+	case BLOB:
+		return "varbinary(max)"
+	}
+
+	return columnType(prop)
+}
+
+func (d mssqlDialect) ColumnDefinition(table string, column *TableColumn) string {
+	name := d.QuoteIdent(column.Name)
+
+	// Computed columns take the type of their expression, only persisted ones
+	// can be declared NOT NULL.
+	if prop := column.Property; prop.GeneratedAs != "" {
+		stmt := name + " AS (" + prop.GeneratedAs + ")"
+
+		if prop.Stored {
+			stmt += " PERSISTED"
+
+			if !prop.Nullable {
+				stmt += " NOT NULL"
+			}
+		}
+
+		if prop.Unique {
+			stmt += " UNIQUE"
+		}
+
+		if prop.PrimaryKey {
+			stmt += " PRIMARY KEY"
+		}
+
+		if prop.Check != "" {
+			stmt += " CHECK (" + prop.Check + ")"
+		}
+
+		return stmt
+	}
+
+	stmt := name + " " + d.ColumnType(column.Property)
+
+	if column.Property.AutoIncrement {
+		stmt += " IDENTITY(1,1) PRIMARY KEY"
+	}
+
+	if column.Property.Type == ENUM {
+		stmt += " CHECK (" + name + " IN (" + quoteLiterals(d, column.Property.EnumOptions) + "))"
+	}
+
+	return stmt + columnModifiers(column.Property, d.defaultValue(column.Property.Default))
+}
+
+func (mssqlDialect) TypeStatements(table string, column *TableColumn) []string {
+	return nil
+}
+
+func (d mssqlDialect) CreateTable(table string, definitions []string) string {
+	return "IF OBJECT_ID(N'" + escapeLiteral(d.QuoteIdent(table)) + "', N'U') IS NULL CREATE TABLE " + d.QuoteIdent(table) + "(" + strings.Join(definitions, ",") + ")"
+}
+
+func (d mssqlDialect) AddColumn(table string, column *TableColumn) string {
+	return alterTablePrefix(d, table) + "ADD " + d.ColumnDefinition(table, column)
+}
+
+// ChangeColumn only alters the type and nullability, SQL Server keeps column
+// defaults in separate constraints.
+func (d mssqlDialect) ChangeColumn(table string, column *TableColumn) []string {
+	stmt := alterTablePrefix(d, table) + "ALTER COLUMN " + d.QuoteIdent(column.Name) + " " + d.ColumnType(column.Property)
+
+	if column.Property.Nullable {
+		stmt += " NULL"
+	} else {
+		stmt += " NOT NULL"
+	}
+
+	return []string{stmt}
+}
+
+func (d mssqlDialect) RenameColumn(table string, from string, to string) string {
+	return mssqlRename(d.QuoteIdent(table)+"."+d.QuoteIdent(from), to, "COLUMN")
+}
+
+func (d mssqlDialect) DropColumn(table string, column string) string {
+	return alterTablePrefix(d, table) + "DROP COLUMN " + d.QuoteIdent(column)
+}
+
+func (d mssqlDialect) DropIndex(table string, index string) string {
+	return "DROP INDEX " + d.QuoteIdent(index) + " ON " + d.QuoteIdent(table)
+}
+
+func (d mssqlDialect) DropConstraint(table string, name string, constraintType ConstraintType) string {
+	return alterTablePrefix(d, table) + "DROP CONSTRAINT " + d.QuoteIdent(name)
+}
+
+func (d mssqlDialect) DropTable(table string, ifExists bool, cascade bool) []string {
+	return []string{standardDropTable(d, table, ifExists)}
+}
+
+func (d mssqlDialect) RenameTable(from string, to string) []string {
+	return []string{mssqlRename(d.QuoteIdent(from), to, "")}
+}
+
+func (mssqlDialect) TableExistsQuery() string {
+	return "SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = SCHEMA_NAME() AND TABLE_NAME = @p1"
+}
+
+func (d mssqlDialect) defaultValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return ""
+	case generatedUUID:
+		return "NEWID()"
+	}
+
+	return d.Literal(value)
+}
+
+func mssqlRename(from string, to string, kind string) string {
+	stmt := "EXEC sp_rename N'" + escapeLiteral(from) + "', N'" + escapeLiteral(to) + "'"
+
+	if kind != "" {
+		stmt += ", N'" + kind + "'"
+	}
+
+	return stmt
+}
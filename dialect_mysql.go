@@ -0,0 +1,169 @@
+package gomigrator
+
+import "strings"
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() SQLDialect {
+	return MYSQL
+}
+
+func (mysqlDialect) Features() DialectFeatures {
+	// InnoDB rejects SET DEFAULT.
+	return DialectFeatures{
+		AlterColumn:        true,
+		AlterConstraint:    true,
+		VirtualColumns:     true,
+		ReferentialActions: []string{ActionCascade, ActionSetNull, ActionRestrict, ActionNoAction},
+	}
+}
+
+func (mysqlDialect) QuoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// QuoteLiteral escapes backslashes as well, MySQL treats them as escape
+// characters unless NO_BACKSLASH_ESCAPES is set.
+func (mysqlDialect) QuoteLiteral(value string) string {
+	return standardQuoteLiteral(strings.ReplaceAll(value, `\`, `\\`))
+}
+
+func (d mysqlDialect) Literal(value interface{}) string {
+	return formatLiteral(d, value, literalFormat{True: "TRUE", False: "FALSE", Time: "2006-01-02 15:04:05.999999", Bytes: "X'%X'"})
+}
+
+func (mysqlDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (d mysqlDialect) ColumnType(prop *SQLTableProp) string {
+	switch prop.Type {
+	case UUID:
+		return "varchar(36)"
+	case JSONB:
+		return string(JSON)
+	case BLOB:
+		return mysqlBlobType(prop.Size)
+	case TIMESTAMPTZ:
+		return temporalType(string(TIMESTAMP), prop.Precision)
+	case ENUM:
+		return "enum(" + quoteLiterals(d, prop.EnumOptions) + ")"
+	}
+
+	return columnType(prop)
+}
+
+func (d mysqlDialect) ColumnDefinition(table string, column *TableColumn) string {
+	stmt := d.QuoteIdent(column.Name) + " " + d.ColumnType(column.Property)
+
+	if column.Property.Unsigned {
+		stmt += " UNSIGNED"
+	}
+
+	if column.Property.AutoIncrement {
+		stmt += " AUTO_INCREMENT PRIMARY KEY"
+	}
+
+	stmt += generatedColumn(column.Property, "AS")
+	defaultValue := d.defaultValue(column.Property.Default)
+
+	// JSON and blob columns only accept expressions as default.
+	if _, raw := column.Property.Default.(Raw); defaultValue != "" && !raw && mysqlExpressionDefault(column.Property.Type) {
+		defaultValue = "(" + defaultValue + ")"
+	}
+
+	stmt += columnModifiers(column.Property, defaultValue)
+
+	if column.Property.UseCurrentOnUpdate {
+		stmt += " ON UPDATE " + temporalType("CURRENT_TIMESTAMP", column.Property.Precision)
+	}
+
+	return stmt
+}
+
+func (mysqlDialect) TypeStatements(table string, column *TableColumn) []string {
+	return nil
+}
+
+func (d mysqlDialect) CreateTable(table string, definitions []string) string {
+	return standardCreateTable(d, table, definitions)
+}
+
+func (d mysqlDialect) AddColumn(table string, column *TableColumn) string {
+	return alterTablePrefix(d, table) + "ADD COLUMN " + d.ColumnDefinition(table, column)
+}
+
+func (d mysqlDialect) ChangeColumn(table string, column *TableColumn) []string {
+	return []string{alterTablePrefix(d, table) + "MODIFY COLUMN " + d.ColumnDefinition(table, column)}
+}
+
+func (d mysqlDialect) RenameColumn(table string, from string, to string) string {
+	return alterTablePrefix(d, table) + "RENAME COLUMN " + d.QuoteIdent(from) + " TO " + d.QuoteIdent(to)
+}
+
+func (d mysqlDialect) DropColumn(table string, column string) string {
+	return alterTablePrefix(d, table) + "DROP COLUMN " + d.QuoteIdent(column)
+}
+
+func (d mysqlDialect) DropIndex(table string, index string) string {
+	return "DROP INDEX " + d.QuoteIdent(index) + " ON " + d.QuoteIdent(table)
+}
+
+// DropConstraint uses the statement of each constraint type, MySQL has no
+// generic DROP CONSTRAINT before 8.0.19.
+func (d mysqlDialect) DropConstraint(table string, name string, constraintType ConstraintType) string {
+	switch constraintType {
+	case PrimaryKeyConstraint:
+		return alterTablePrefix(d, table) + "DROP PRIMARY KEY"
+	case UniqueConstraint:
+		return alterTablePrefix(d, table) + "DROP INDEX " + d.QuoteIdent(name)
+	case CheckConstraint:
+		return alterTablePrefix(d, table) + "DROP CHECK " + d.QuoteIdent(name)
+	case ForeignKeyConstraint:
+		return alterTablePrefix(d, table) + "DROP FOREIGN KEY " + d.QuoteIdent(name)
+	}
+
+	return alterTablePrefix(d, table) + "DROP CONSTRAINT " + d.QuoteIdent(name)
+}
+
+func (d mysqlDialect) DropTable(table string, ifExists bool, cascade bool) []string {
+	return []string{standardDropTable(d, table, ifExists)}
+}
+
+func (d mysqlDialect) RenameTable(from string, to string) []string {
+	return []string{alterTablePrefix(d, from) + "RENAME TO " + d.QuoteIdent(to)}
+}
+
+func (mysqlDialect) TableExistsQuery() string {
+	return "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+}
+
+func (d mysqlDialect) defaultValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return ""
+	case generatedUUID:
+		return "uuid()"
+	}
+
+	return d.Literal(value)
+}
+
+func mysqlExpressionDefault(dataType SQLDataType) bool {
+	return dataType == JSON || dataType == JSONB || dataType == BLOB
+}
+
+func mysqlBlobType(size int) string {
+	switch {
+	case size <= 0:
+		return "blob"
+	case size <= 255:
+		return "tinyblob"
+	case size <= 65535:
+		return "blob"
+	case size <= 16777215:
+		return "mediumblob"
+	}
+
+	return "longblob"
+}
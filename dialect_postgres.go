@@ -0,0 +1,275 @@
+package gomigrator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() SQLDialect {
+	return POSTGRES
+}
+
+func (postgresDialect) Features() DialectFeatures {
+	return DialectFeatures{
+		TransactionalDDL:      true,
+		AlterColumn:           true,
+		AlterConstraint:       true,
+		GinIndex:              true,
+		IntervalType:          true,
+		ArrayType:             true,
+		UniqueOptions:         true,
+		DeferrableConstraints: true,
+		ConstraintValidation:  true,
+		ReferentialActions:    referentialActions,
+	}
+}
+
+func (postgresDialect) QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (postgresDialect) QuoteLiteral(value string) string {
+	return standardQuoteLiteral(value)
+}
+
+func (d postgresDialect) Literal(value interface{}) string {
+	return formatLiteral(d, value, literalFormat{True: "TRUE", False: "FALSE", Time: "2006-01-02 15:04:05.999999Z07:00", Bytes: `'\x%x'`})
+}
+
+func (postgresDialect) Placeholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func (d postgresDialect) ColumnType(prop *SQLTableProp) string {
+	if prop.AutoIncrement && !prop.Identity {
+		if prop.Type == BIGINT || prop.Type == BIGSERIAL {
+			return string(BIGSERIAL)
+		}
+
+		return string(SERIAL)
+	}
+
+	switch prop.Type {
+	case DECIMAL:
+		return fmt.Sprintf("numeric(%d, %d)", prop.Precision, prop.Scale)
+	case BINARY, VARBINARY, BLOB:
+		return "bytea"
+	case DATETIME:
+		return temporalType(string(TIMESTAMP), prop.Precision)
+	case ARRAY:
+		return postgresDialect{}.ColumnType(&SQLTableProp{Type: prop.ElementType}) + "[]"
+	}
+
+	return columnType(prop)
+}
+
+func (d postgresDialect) ColumnDefinition(table string, column *TableColumn) string {
+	prop := *column.Property
+	prop.PrimaryKey = prop.PrimaryKey || prop.AutoIncrement
+	stmt := d.QuoteIdent(column.Name) + " " + d.columnType(table, column)
+
+	if prop.AutoIncrement && prop.Identity {
+		stmt += " GENERATED BY DEFAULT AS IDENTITY"
+	}
+
+	// Postgres before version 18 only computes stored columns, declaring a
+	// virtual one fails for Postgres tables.
+	prop.Stored = true
+	stmt += generatedColumn(&prop, "GENERATED ALWAYS AS")
+
+	return stmt + columnModifiers(&prop, d.defaultValue(&prop))
+}
+
+func (d postgresDialect) TypeStatements(table string, column *TableColumn) []string {
+	// A changed enum column replaces its type, see ChangeColumn.
+	if column.Property.Type != ENUM || column.Property.Changed || table == "" {
+		return nil
+	}
+
+	return []string{postgresEnsureEnum(d.QuoteIdent(enumTypeName(table, column.Name)), column.Property.EnumOptions)}
+}
+
+func (d postgresDialect) CreateTable(table string, definitions []string) string {
+	return standardCreateTable(d, table, definitions)
+}
+
+func (d postgresDialect) AddColumn(table string, column *TableColumn) string {
+	return alterTablePrefix(d, table) + "ADD COLUMN " + d.ColumnDefinition(table, column)
+}
+
+func (d postgresDialect) ChangeColumn(table string, column *TableColumn) []string {
+	name := d.QuoteIdent(column.Name)
+	prefix := alterTablePrefix(d, table) + "ALTER COLUMN " + name + " "
+	dataType := d.columnType(table, column)
+	statements := []string{}
+
+	switch {
+	case column.Property.Type == ENUM:
+		// Values can not be removed from an enum type, so the column moves to
+		// a new type replacing the old one.
+		newType := d.QuoteIdent(enumTypeName(table, column.Name) + "_new")
+		statements = append(statements,
+			"CREATE TYPE "+newType+" AS ENUM("+quoteLiterals(d, column.Property.EnumOptions)+")",
+			prefix+"DROP DEFAULT",
+			prefix+"TYPE "+newType+" USING "+name+"::text::"+newType,
+			"DROP TYPE IF EXISTS "+dataType,
+			"ALTER TYPE "+newType+" RENAME TO "+dataType,
+		)
+	case column.Property.AutoIncrement:
+		// serial is not a type Postgres can change to, the column keeps its
+		// sequence as its default.
+		prop := *column.Property
+		prop.AutoIncrement = false
+		dataType = d.ColumnType(&prop)
+		statements = append(statements, prefix+"TYPE "+dataType+" USING "+name+"::"+dataType)
+	default:
+		statements = append(statements, prefix+"TYPE "+dataType+" USING "+name+"::"+dataType)
+	}
+
+	if column.Property.Default != nil {
+		statements = append(statements, prefix+"SET DEFAULT "+d.defaultValue(column.Property))
+	}
+
+	if column.Property.Nullable {
+		statements = append(statements, prefix+"DROP NOT NULL")
+	} else {
+		statements = append(statements, prefix+"SET NOT NULL")
+	}
+
+	return statements
+}
+
+func (d postgresDialect) RenameColumn(table string, from string, to string) string {
+	return alterTablePrefix(d, table) + "RENAME COLUMN " + d.QuoteIdent(from) + " TO " + d.QuoteIdent(to)
+}
+
+func (d postgresDialect) DropColumn(table string, column string) string {
+	return alterTablePrefix(d, table) + "DROP COLUMN " + d.QuoteIdent(column)
+}
+
+func (d postgresDialect) DropIndex(table string, index string) string {
+	return "DROP INDEX " + d.QuoteIdent(index)
+}
+
+func (d postgresDialect) DropConstraint(table string, name string, constraintType ConstraintType) string {
+	return alterTablePrefix(d, table) + "DROP CONSTRAINT " + d.QuoteIdent(name)
+}
+
+func (d postgresDialect) DropTable(table string, ifExists bool, cascade bool) []string {
+	stmt := standardDropTable(d, table, ifExists)
+
+	if cascade {
+		stmt += " CASCADE"
+	}
+
+	return []string{stmt, postgresDropEnumTypes(table)}
+}
+
+func (d postgresDialect) RenameTable(from string, to string) []string {
+	return []string{
+		alterTablePrefix(d, from) + "RENAME TO " + d.QuoteIdent(to),
+		postgresRenameEnumTypes(from, to),
+	}
+}
+
+func (postgresDialect) TableExistsQuery() string {
+	return "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1"
+}
+
+func (d postgresDialect) columnType(table string, column *TableColumn) string {
+	if column.Property.Type == ENUM && table != "" {
+		return d.QuoteIdent(enumTypeName(table, column.Name))
+	}
+
+	return d.ColumnType(column.Property)
+}
+
+func (d postgresDialect) defaultValue(prop *SQLTableProp) string {
+	switch prop.Default.(type) {
+	case nil:
+		return ""
+	case generatedUUID:
+		return "gen_random_uuid()"
+	case Raw:
+		return d.Literal(prop.Default)
+	}
+
+	if prop.Type == ARRAY {
+		return d.QuoteLiteral(postgresArray(reflect.ValueOf(prop.Default)))
+	}
+
+	return d.Literal(prop.Default)
+}
+
+// postgresArray renders a slice in the text format of Postgres arrays, like
+// {"a","b"}.
+func postgresArray(value reflect.Value) string {
+	if value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "NULL"
+		}
+
+		return postgresArray(value.Elem())
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		elements := make([]string, value.Len())
+		for i := range elements {
+			elements[i] = postgresArray(value.Index(i))
+		}
+
+		return "{" + strings.Join(elements, ",") + "}"
+	case reflect.String:
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value.String()) + `"`
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+func enumTypeName(table string, column string) string {
+	return table + "_" + column + "_type"
+}
+
+func postgresCreateEnum(name string, options []string) string {
+	return "DROP TYPE IF EXISTS " + name + "; CREATE TYPE " + name + " AS ENUM(" + quoteLiterals(postgresDialect{}, options) + ");"
+}
+
+// postgresEnsureEnum creates the enum type unless it exists, as dropping it
+// fails while a column of an existing table uses it.
+func postgresEnsureEnum(name string, options []string) string {
+	return "DO $$ BEGIN CREATE TYPE " + name + " AS ENUM(" + quoteLiterals(postgresDialect{}, options) + ");" +
+		" EXCEPTION WHEN duplicate_object THEN NULL; END $$;"
+}
+
+// postgresDropEnumTypes removes the enum types created for the table's
+// columns that are no longer used by any column.
+func postgresDropEnumTypes(table string) string {
+	return "DO $$ DECLARE r record; BEGIN FOR r IN SELECT t.typname FROM pg_type t" +
+		" WHERE t.typtype = 'e' AND t.typnamespace = current_schema()::regnamespace" +
+		" AND t.typname LIKE '" + enumTypePattern(table) + "'" +
+		" AND NOT EXISTS (SELECT 1 FROM pg_attribute a WHERE a.atttypid = t.oid)" +
+		" LOOP EXECUTE 'DROP TYPE ' || quote_ident(r.typname); END LOOP; END $$"
+}
+
+// postgresRenameEnumTypes follows a table rename for the enum types its
+// columns use.
+func postgresRenameEnumTypes(from string, to string) string {
+	return "DO $$ DECLARE r record; BEGIN FOR r IN SELECT DISTINCT t.typname FROM pg_type t" +
+		" JOIN pg_attribute a ON a.atttypid = t.oid" +
+		" WHERE a.attrelid = '" + escapeLiteral(postgresDialect{}.QuoteIdent(to)) + "'::regclass AND t.typtype = 'e'" +
+		" AND t.typname LIKE '" + enumTypePattern(from) + "'" +
+		" LOOP EXECUTE 'ALTER TYPE ' || quote_ident(r.typname) || ' RENAME TO '" +
+		" || quote_ident('" + escapeLiteral(to) + "' || substr(r.typname, " + strconv.Itoa(len(from)+1) + "));" +
+		" END LOOP; END $$"
+}
+
+func enumTypePattern(table string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "_", `\_`, "%", `\%`)
+
+	return escapeLiteral(replacer.Replace(table)) + `\_%\_type`
+}
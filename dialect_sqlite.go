@@ -0,0 +1,384 @@
+package gomigrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sqliteUUID generates a random version 4 UUID, as SQLite has no function
+// for it.
+const sqliteUUID = "(lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || " +
+	"substr('89ab', abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))))"
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() SQLDialect {
+	return SQLITE
+}
+
+func (sqliteDialect) Features() DialectFeatures {
+	return DialectFeatures{
+		TransactionalDDL:      true,
+		DeferrableConstraints: true,
+		VirtualColumns:        true,
+		ReferentialActions:    referentialActions,
+	}
+}
+
+func (sqliteDialect) QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (sqliteDialect) QuoteLiteral(value string) string {
+	return standardQuoteLiteral(value)
+}
+
+func (d sqliteDialect) Literal(value interface{}) string {
+	return formatLiteral(d, value, literalFormat{True: "1", False: "0", Time: "2006-01-02 15:04:05.999999999Z07:00", Bytes: "X'%X'"})
+}
+
+func (sqliteDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (sqliteDialect) ColumnType(prop *SQLTableProp) string {
+	switch prop.Type {
+	case INT, TINYINT, MEDIUMINT, BIGINT, SERIAL, BIGSERIAL, BOOL:
+		return "integer"
+	case FLOAT, DOUBLE, REAL, DOUBLE_PRECISION:
+		return "real"
+	case CHAR, VARCHAR, TEXT, ENUM, UUID, JSON, JSONB:
+		return "text"
+	case DECIMAL:
+		return "numeric"
+	case BINARY, VARBINARY, BLOB:
+		return "blob"
+	case TIMESTAMPTZ:
+		return string(TIMESTAMP)
+	}
+
+	return string(prop.Type)
+}
+
+func (d sqliteDialect) ColumnDefinition(table string, column *TableColumn) string {
+	name := d.QuoteIdent(column.Name)
+	stmt := name + " " + d.ColumnType(column.Property)
+
+	if column.Property.AutoIncrement {
+		stmt += " PRIMARY KEY AUTOINCREMENT"
+	}
+
+	if column.Property.Type == ENUM {
+		stmt += " CHECK (" + name + " IN (" + quoteLiterals(d, column.Property.EnumOptions) + "))"
+	}
+
+	stmt += generatedColumn(column.Property, "GENERATED ALWAYS AS")
+
+	return stmt + columnModifiers(column.Property, d.defaultValue(column.Property.Default))
+}
+
+func (sqliteDialect) TypeStatements(table string, column *TableColumn) []string {
+	return nil
+}
+
+func (d sqliteDialect) CreateTable(table string, definitions []string) string {
+	return standardCreateTable(d, table, definitions)
+}
+
+func (d sqliteDialect) AddColumn(table string, column *TableColumn) string {
+	return alterTablePrefix(d, table) + "ADD COLUMN " + d.ColumnDefinition(table, column)
+}
+
+// ChangeColumn is never called, SQLite changes columns through RebuildTable.
+func (sqliteDialect) ChangeColumn(table string, column *TableColumn) []string {
+	return nil
+}
+
+func (d sqliteDialect) RenameColumn(table string, from string, to string) string {
+	return alterTablePrefix(d, table) + "RENAME COLUMN " + d.QuoteIdent(from) + " TO " + d.QuoteIdent(to)
+}
+
+func (d sqliteDialect) DropColumn(table string, column string) string {
+	return alterTablePrefix(d, table) + "DROP COLUMN " + d.QuoteIdent(column)
+}
+
+func (d sqliteDialect) DropIndex(table string, index string) string {
+	return "DROP INDEX " + d.QuoteIdent(index)
+}
+
+func (d sqliteDialect) DropConstraint(table string, name string, constraintType ConstraintType) string {
+	return alterTablePrefix(d, table) + "DROP CONSTRAINT " + d.QuoteIdent(name)
+}
+
+func (d sqliteDialect) DropTable(table string, ifExists bool, cascade bool) []string {
+	return []string{standardDropTable(d, table, ifExists)}
+}
+
+func (d sqliteDialect) RenameTable(from string, to string) []string {
+	return []string{alterTablePrefix(d, from) + "RENAME TO " + d.QuoteIdent(to)}
+}
+
+func (sqliteDialect) TableExistsQuery() string {
+	return "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+}
+
+func (d sqliteDialect) defaultValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return ""
+	case generatedUUID:
+		return sqliteUUID
+	}
+
+	// Expressions have to be parenthesized in a SQLite default clause.
+	if expr, ok := value.(Raw); ok && !strings.HasPrefix(string(expr), "(") {
+		return "(" + string(expr) + ")"
+	}
+
+	return d.Literal(value)
+}
+
+// RebuildTable applies the changed columns and new table constraints the
+// way SQLite documents it: a new table is created from the edited original
+// definition, the rows are copied over, the original is dropped and the new
+// table takes its name. Indexes and triggers are recreated afterwards.
+//
+// Dropping the original would fire the ON DELETE actions of referencing
+// tables, so the rebuild is refused while foreign key enforcement is on.
+func (d sqliteDialect) RebuildTable(db Execer, t *Table) error {
+	var foreignKeys int
+
+	if err := db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		return err
+	}
+
+	// Dropping the table would delete or reject the rows referencing it.
+	if foreignKeys == 1 {
+		return fmt.Errorf("cannot rebuild table %s while PRAGMA foreign_keys is on", t.Name)
+	}
+
+	var createSQL string
+
+	err := db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", t.Name).Scan(&createSQL)
+
+	if err != nil {
+		return fmt.Errorf("table %s: %w", t.Name, err)
+	}
+
+	open, close := strings.Index(createSQL, "("), strings.LastIndex(createSQL, ")")
+
+	if open < 0 || close < open {
+		return errors.New("could not parse the definition of table " + t.Name)
+	}
+
+	definitions := []string{}
+	columns := []string{}
+	changed := map[string]bool{}
+	dropped := map[string]bool{}
+
+	for _, definition := range splitDefinitions(createSQL[open+1 : close]) {
+		if isTableConstraint(definition) {
+			if constraint, ok := sqliteDropsConstraint(t, definition); ok {
+				dropped[constraint.Name] = true
+				continue
+			}
+
+			definitions = append(definitions, definition)
+			continue
+		}
+
+		name := definitionName(definition)
+		columns = append(columns, name)
+
+		for _, column := range t.Blueprint.Columns {
+			if column.Property.Changed && column.Name == name {
+				definition = t.columnDefinition(column)
+				changed[name] = true
+			}
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	for _, column := range t.Blueprint.Columns {
+		if column.Property.Changed && !changed[column.Name] {
+			return fmt.Errorf("table %s has no column %s to change", t.Name, column.Name)
+		}
+	}
+
+	for _, constraint := range t.DroppedConstraints() {
+		if !dropped[constraint.Name] {
+			return fmt.Errorf("table %s has no constraint %s to drop", t.Name, constraint.Name)
+		}
+	}
+
+	rows, err := db.Query("SELECT sql FROM sqlite_master WHERE tbl_name = ? AND type IN ('index', 'trigger') AND sql IS NOT NULL", t.Name)
+
+	if err != nil {
+		return err
+	}
+
+	recreate := []string{}
+	for rows.Next() {
+		var stmt string
+
+		if err := rows.Scan(&stmt); err != nil {
+			rows.Close()
+			return err
+		}
+
+		recreate = append(recreate, stmt)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	table, temporary := d.QuoteIdent(t.Name), d.QuoteIdent(t.Name+"_rebuild")
+	columnList := quoteIdents(d, columns)
+	definitions = append(definitions, t.TableConstraints...)
+
+	statements := []string{
+		"CREATE TABLE " + temporary + "(" + strings.Join(definitions, ",") + ")",
+		"INSERT INTO " + temporary + " (" + columnList + ") SELECT " + columnList + " FROM " + table,
+		"DROP TABLE " + table,
+		"ALTER TABLE " + temporary + " RENAME TO " + table,
+	}
+
+	if err := execStatements(append(statements, recreate...), db); err != nil {
+		return err
+	}
+
+	return sqliteForeignKeyCheck(db, t.Name)
+}
+
+// PrepareRebuild turns foreign keys off, as SQLite ignores the pragma inside
+// a transaction, and turns them back on afterwards.
+func (sqliteDialect) PrepareRebuild(conn *sql.Conn) (func() error, error) {
+	ctx := context.Background()
+	var foreignKeys int
+
+	if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		return nil, err
+	}
+
+	if foreignKeys == 0 {
+		return func() error { return nil }, nil
+	}
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		_, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+		return err
+	}, nil
+}
+
+// sqliteForeignKeyCheck fails the rebuild of table when rows of the database
+// violate a foreign key, which the rebuild could not enforce.
+func sqliteForeignKeyCheck(db Execer, table string) error {
+	rows, err := db.Query("PRAGMA foreign_key_check")
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	if rows.Next() {
+		var child, parent string
+		var rowid sql.NullInt64
+		var foreignKey int
+
+		if err := rows.Scan(&child, &rowid, &parent, &foreignKey); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("rebuilding table %s: a row of %s violates its foreign key to %s", table, child, parent)
+	}
+
+	return rows.Err()
+}
+
+// splitDefinitions splits the body of a CREATE TABLE statement on the commas
+// separating column definitions and table constraints.
+func splitDefinitions(body string) []string {
+	definitions := []string{}
+	depth, start := 0, 0
+	var quote rune
+
+	for i, r := range body {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '[':
+			quote = ']'
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			definitions = appendDefinition(definitions, body[start:i])
+			start = i + 1
+		}
+	}
+
+	return appendDefinition(definitions, body[start:])
+}
+
+func appendDefinition(definitions []string, definition string) []string {
+	if definition = strings.TrimSpace(definition); definition == "" {
+		return definitions
+	}
+
+	return append(definitions, definition)
+}
+
+func isTableConstraint(definition string) bool {
+	keyword := strings.ToUpper(strings.Fields(definition)[0])
+
+	return slices.Contains([]string{"CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN"}, keyword)
+}
+
+// sqliteDropsConstraint matches a table constraint against the dropped ones, by
+// name or, as SQLite does not name it, by type for the primary key.
+func sqliteDropsConstraint(t *Table, definition string) (DroppedConstraint, bool) {
+	name, body := "", definition
+
+	if strings.EqualFold(strings.Fields(definition)[0], "CONSTRAINT") {
+		body = strings.TrimSpace(definition[len("CONSTRAINT"):])
+		name = definitionName(body)
+		body = strings.TrimSpace(body[len(strings.Fields(body)[0]):])
+	}
+
+	for _, constraint := range t.DroppedConstraints() {
+		if name == constraint.Name || constraint.Type == PrimaryKeyConstraint && strings.HasPrefix(strings.ToUpper(body), "PRIMARY KEY") {
+			return constraint, true
+		}
+	}
+
+	return DroppedConstraint{}, false
+}
+
+func definitionName(definition string) string {
+	name := strings.Fields(definition)[0]
+
+	if len(name) > 1 && strings.ContainsRune("\"`[", rune(name[0])) {
+		quote := name[len(name)-1:]
+		name = strings.ReplaceAll(name[1:len(name)-1], quote+quote, quote)
+	}
+
+	return name
+}
@@ -0,0 +1,113 @@
+package gomigrator
+
+import (
+	"testing"
+	"time"
+)
+
+type cockroachDialect struct {
+	postgresDialect
+}
+
+func (cockroachDialect) Name() SQLDialect {
+	return "cockroach"
+}
+
+func (cockroachDialect) Features() DialectFeatures {
+	return DialectFeatures{AlterColumn: true, AlterConstraint: true}
+}
+
+// registerTestDialect registers dialect until the test ends, so that tests
+// can run more than once.
+func registerTestDialect(t *testing.T, dialect Dialect) {
+	RegisterDialect(dialect)
+
+	t.Cleanup(func() {
+		dialectsMu.Lock()
+		defer dialectsMu.Unlock()
+
+		delete(dialects, dialect.Name())
+	})
+}
+
+func TestRegisterDialect(t *testing.T) {
+	registerTestDialect(t, cockroachDialect{})
+
+	table := CreateTable("users", func(t *Blueprint) {
+		t.Increment("id")
+		t.Varchar("name", 50, nil)
+	}, "cockroach")
+
+	stmt := table.statements()[0]
+	expected := `CREATE TABLE IF NOT EXISTS "users"("id" serial PRIMARY KEY,"name" varchar(50) NOT NULL)`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering a dialect twice to panic")
+		}
+	}()
+
+	RegisterDialect(cockroachDialect{})
+}
+
+func TestLookupDialect(t *testing.T) {
+	dialect, err := LookupDialect(MSSQL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dialect.Placeholder(2) != "@p2" {
+		t.Errorf("Expected the SQL Server placeholder, and got %q", dialect.Placeholder(2))
+	}
+
+	if _, err := LookupDialect("oracle"); err == nil {
+		t.Error("Expected an unknown dialect to return an error")
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	placedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var missing *string
+
+	tests := []struct {
+		dialect  SQLDialect
+		value    interface{}
+		expected string
+	}{
+		{POSTGRES, "it's ()", "'it''s ()'"},
+		{POSTGRES, Raw("now() + interval '1 day'"), "now() + interval '1 day'"},
+		{POSTGRES, true, "TRUE"},
+		{MSSQL, false, "0"},
+		{MYSQL, int64(-3), "-3"},
+		{MYSQL, 2.5, "2.5"},
+		{MYSQL, placedAt, "'2024-01-02 03:04:05'"},
+		{POSTGRES, placedAt, "'2024-01-02 03:04:05Z'"},
+		{SQLITE, nil, "NULL"},
+		{SQLITE, missing, "NULL"},
+	}
+
+	for _, test := range tests {
+		if literal := dialectOf(test.dialect).Literal(test.value); literal != test.expected {
+			t.Errorf("Expected: %s, but got %q", test.expected, literal)
+		}
+	}
+}
+
+func TestRawDefaultSqlite(t *testing.T) {
+	table := CreateTable("users", func(t *Blueprint) {
+		t.Timestamp("created_at", &TextColumnProps{Default: Raw("CURRENT_TIMESTAMP")})
+		t.Varchar("nickname", 20, &TextColumnProps{Nullable: true, Default: Null})
+	}, SQLITE)
+
+	stmt := parseTableTemplate(table)
+	expected := `CREATE TABLE IF NOT EXISTS "users"("created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),"nickname" text NULL DEFAULT (NULL))`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
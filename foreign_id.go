@@ -0,0 +1,127 @@
+package gomigrator
+
+import "strings"
+
+// ForeignIdColumn is a column referencing the key of another table, the
+// foreign key is declared once it is Constrained or given a reference.
+type ForeignIdColumn struct {
+	*TableColumn
+	constrained bool
+	table       string
+	column      string
+	onDelete    string
+	onUpdate    string
+}
+
+// ForeignId adds a BIGINT column matching the keys of BigIncrement, see
+// Unsigned for keys declared as BIGINT UNSIGNED.
+func (b *Blueprint) ForeignId(name string) *ForeignIdColumn {
+	return b.foreignId(b.AddColumn(name, SQLTableProp{Type: BIGINT}))
+}
+
+// ForeignUuid adds a UUID column matching the keys of Uuid.
+func (b *Blueprint) ForeignUuid(name string) *ForeignIdColumn {
+	return b.foreignId(b.AddColumn(name, SQLTableProp{Type: UUID}))
+}
+
+func (b *Blueprint) foreignId(column *TableColumn) *ForeignIdColumn {
+	foreign := &ForeignIdColumn{TableColumn: column}
+	b.foreignIds = append(b.foreignIds, foreign)
+
+	return foreign
+}
+
+// Constrained references the id column of the table named after the column,
+// user_id references users.
+func (c *ForeignIdColumn) Constrained() *ForeignIdColumn {
+	c.constrained = true
+
+	return c
+}
+
+func (c *ForeignIdColumn) References(column string) *ForeignIdColumn {
+	c.constrained = true
+	c.column = column
+
+	return c
+}
+
+func (c *ForeignIdColumn) On(table string) *ForeignIdColumn {
+	c.constrained = true
+	c.table = table
+
+	return c
+}
+
+// Unsigned matches a referenced key declared as BIGINT UNSIGNED, which MySQL
+// requires of the foreign key column as well.
+func (c *ForeignIdColumn) Unsigned() *ForeignIdColumn {
+	c.Property.Unsigned = true
+
+	return c
+}
+
+func (c *ForeignIdColumn) Nullable() *ForeignIdColumn {
+	c.Property.Nullable = true
+
+	return c
+}
+
+func (c *ForeignIdColumn) CascadeOnDelete() *ForeignIdColumn {
+	c.onDelete = ActionCascade
+
+	return c
+}
+
+func (c *ForeignIdColumn) RestrictOnDelete() *ForeignIdColumn {
+	c.onDelete = ActionRestrict
+
+	return c
+}
+
+// NullOnDelete makes the column nullable, to clear it when the referenced row
+// is deleted.
+func (c *ForeignIdColumn) NullOnDelete() *ForeignIdColumn {
+	c.onDelete = ActionSetNull
+
+	return c.Nullable()
+}
+
+func (c *ForeignIdColumn) CascadeOnUpdate() *ForeignIdColumn {
+	c.onUpdate = ActionCascade
+
+	return c
+}
+
+func (c *ForeignIdColumn) foreignKeyOptions() *ForeignKeyOptions {
+	options := &ForeignKeyOptions{
+		ReferenceTable:  c.table,
+		ReferenceColumn: c.column,
+		OnDelete:        c.onDelete,
+		OnUpdate:        c.onUpdate,
+	}
+
+	if options.ReferenceTable == "" {
+		options.ReferenceTable = pluralize(strings.TrimSuffix(c.Name, "_id"))
+	}
+
+	if options.ReferenceColumn == "" {
+		options.ReferenceColumn = "id"
+	}
+
+	return options
+}
+
+// pluralize covers the regular English plurals, irregular table names are
+// given with On.
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	}
+
+	return word + "s"
+}
@@ -0,0 +1,45 @@
+package gomigrator
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestForeignId(t *testing.T) {
+	table := CreateTable("posts", func(t *Blueprint) {
+		t.BigIncrement("id")
+		t.ForeignId("user_id").Constrained()
+		t.ForeignId("category_id").NullOnDelete().Constrained()
+		t.ForeignUuid("author_uuid").References("uuid").On("people").CascadeOnDelete()
+		t.ForeignId("legacy_id").Unsigned()
+	}, MYSQL)
+
+	stmt := parseTableTemplate(table)
+	expected := "CREATE TABLE IF NOT EXISTS `posts`(`id` bigint AUTO_INCREMENT PRIMARY KEY,`user_id` bigint NOT NULL," +
+		"`category_id` bigint NULL,`author_uuid` varchar(36) NOT NULL,`legacy_id` bigint UNSIGNED NOT NULL)"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	statements := table.ForeignKeyStatements
+	expectedStatements := []string{
+		"ALTER TABLE `posts` ADD CONSTRAINT `posts_user_id_fkey` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`);",
+		"ALTER TABLE `posts` ADD CONSTRAINT `posts_category_id_fkey` FOREIGN KEY (`category_id`) REFERENCES `categories`(`id`) ON DELETE SET NULL;",
+		"ALTER TABLE `posts` ADD CONSTRAINT `posts_author_uuid_fkey` FOREIGN KEY (`author_uuid`) REFERENCES `people`(`uuid`) ON DELETE CASCADE;",
+	}
+
+	if !slices.Equal(statements, expectedStatements) {
+		t.Errorf("Expected: %v, and got %q", expectedStatements, statements)
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	words := map[string]string{"user": "users", "category": "categories", "day": "days", "address": "addresses", "match": "matches"}
+
+	for word, expected := range words {
+		if plural := pluralize(word); plural != expected {
+			t.Errorf("Expected: %s, and got %q", expected, plural)
+		}
+	}
+}
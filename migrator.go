@@ -0,0 +1,364 @@
+package gomigrator
+
+import (
+	"cmp"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const DefaultMigrationTable = "schema_migrations"
+
+var registry = &Migrator{}
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(schema *Schema)
+	Down    func(schema *Schema)
+}
+
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Batch     int
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	DB        *sql.DB
+	Dialect   SQLDialect
+	TableName string
+	// Pretend writes the statements of Migrate and Rollback to Output instead
+	// of executing them. The tracking table is only read, never created.
+	Pretend    bool
+	Output     io.Writer
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, dialect SQLDialect) *Migrator {
+	return &Migrator{
+		DB:        db,
+		Dialect:   dialect,
+		TableName: DefaultMigrationTable,
+		Output:    os.Stdout,
+	}
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%d_%s", m.Version, m.Name)
+}
+
+// Register adds migrations, none of them when one is invalid.
+func (m *Migrator) Register(migrations ...Migration) error {
+	for i, migration := range migrations {
+		if migration.Version <= 0 {
+			return fmt.Errorf("migration %q must have a positive version", migration.Name)
+		}
+
+		if migration.Up == nil {
+			return fmt.Errorf("migration %s has no Up function", migration)
+		}
+
+		for _, registered := range append(slices.Clone(m.migrations), migrations[:i]...) {
+			if registered.Version == migration.Version {
+				return fmt.Errorf("migration version %d is already registered as %s", migration.Version, registered)
+			}
+		}
+	}
+
+	m.migrations = append(m.migrations, migrations...)
+
+	slices.SortFunc(m.migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return nil
+}
+
+// Register adds migrations to the package registry, usually from the init
+// function of a migration file. It panics when a migration is invalid or its
+// version is already registered.
+func Register(migrations ...Migration) {
+	if err := registry.Register(migrations...); err != nil {
+		panic("gomigrator: " + err.Error())
+	}
+}
+
+func RegisteredMigrations() []Migration {
+	return registry.Migrations()
+}
+
+func (m *Migrator) Migrations() []Migration {
+	return slices.Clone(m.migrations)
+}
+
+func (m *Migrator) Applied() ([]AppliedMigration, error) {
+	if m.Pretend {
+		exists, err := tableExists(m.DB, dialectOf(m.Dialect), m.TableName)
+
+		if err != nil || !exists {
+			return []AppliedMigration{}, err
+		}
+	} else if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.DB.Query("SELECT version, name, batch, applied_at FROM " + m.table() + " ORDER BY version")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	applied := []AppliedMigration{}
+	for rows.Next() {
+		var record AppliedMigration
+		var appliedAt interface{}
+
+		if err := rows.Scan(&record.Version, &record.Name, &record.Batch, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		if record.AppliedAt, err = parseAppliedAt(appliedAt); err != nil {
+			return nil, err
+		}
+
+		applied = append(applied, record)
+	}
+
+	return applied, rows.Err()
+}
+
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.Applied()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m.pending(applied), nil
+}
+
+func (m *Migrator) pending(applied []AppliedMigration) []Migration {
+	pending := []Migration{}
+	for _, migration := range m.migrations {
+		isApplied := slices.ContainsFunc(applied, func(a AppliedMigration) bool {
+			return a.Version == migration.Version
+		})
+
+		if !isApplied {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending
+}
+
+func (m *Migrator) Migrate() error {
+	applied, err := m.Applied()
+
+	if err != nil {
+		return err
+	}
+
+	batch := 0
+	for _, record := range applied {
+		batch = max(batch, record.Batch)
+	}
+
+	for _, migration := range m.pending(applied) {
+		if err := m.apply(migration, batch+1); err != nil {
+			return fmt.Errorf("migration %s: %w", migration, err)
+		}
+	}
+
+	return nil
+}
+
+// Rollback reverts the last batch when steps is zero or less, otherwise the
+// last steps applied migrations, most recently applied first.
+func (m *Migrator) Rollback(steps int) error {
+	applied, err := m.Applied()
+
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		return nil
+	}
+
+	// Versions merged from other branches can be applied after newer ones, so
+	// the order of the batches decides what was applied last.
+	slices.SortFunc(applied, func(a, b AppliedMigration) int {
+		return cmp.Or(cmp.Compare(b.Batch, a.Batch), cmp.Compare(b.Version, a.Version))
+	})
+
+	if steps <= 0 {
+		lastBatch := applied[0].Batch
+		steps = slices.IndexFunc(applied, func(a AppliedMigration) bool {
+			return a.Batch != lastBatch
+		})
+
+		if steps < 0 {
+			steps = len(applied)
+		}
+	}
+
+	if steps > len(applied) {
+		steps = len(applied)
+	}
+
+	for _, record := range applied[:steps] {
+		migration, ok := m.find(record.Version)
+
+		if !ok {
+			return fmt.Errorf("migration %d_%s is applied but not registered", record.Version, record.Name)
+		}
+
+		if err := m.revert(migration); err != nil {
+			return fmt.Errorf("rollback %s: %w", migration, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) Reset() error {
+	applied, err := m.Applied()
+
+	if err != nil {
+		return err
+	}
+
+	return m.Rollback(len(applied))
+}
+
+func (m *Migrator) Refresh() error {
+	if err := m.Reset(); err != nil {
+		return err
+	}
+
+	return m.Migrate()
+}
+
+func (m *Migrator) find(version int64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+
+	return Migration{}, false
+}
+
+func (m *Migrator) apply(migration Migration, batch int) error {
+	schema := NewSchema(m.Dialect)
+	migration.Up(schema)
+
+	stmt := "INSERT INTO " + m.table() + " (version, name, batch, applied_at) VALUES (" +
+		m.placeholder(1) + ", " + m.placeholder(2) + ", " + m.placeholder(3) + ", " + m.placeholder(4) + ")"
+
+	return m.run(migration, schema, stmt, migration.Version, migration.Name, batch, time.Now().UTC())
+}
+
+func (m *Migrator) revert(migration Migration) error {
+	if migration.Down == nil {
+		return fmt.Errorf("migration %s has no Down function", migration)
+	}
+
+	schema := NewSchema(m.Dialect)
+	migration.Down(schema)
+
+	return m.run(migration, schema, "DELETE FROM "+m.table()+" WHERE version = "+m.placeholder(1), migration.Version)
+}
+
+// run executes the schema and its bookkeeping statement atomically when the
+// dialect allows it.
+func (m *Migrator) run(migration Migration, schema *Schema, stmt string, args ...interface{}) error {
+	if m.Pretend {
+		return m.pretend(migration, schema)
+	}
+
+	if dialectOf(m.Dialect).Features().TransactionalDDL {
+		return transaction(m.DB, schema.Tables, func(tx *sql.Tx) error {
+			if err := schema.exec(tx); err != nil {
+				return err
+			}
+
+			_, err := tx.Exec(stmt, args...)
+
+			return err
+		})
+	}
+
+	if err := schema.Run(m.DB); err != nil {
+		return err
+	}
+
+	_, err := m.DB.Exec(stmt, args...)
+
+	return err
+}
+
+func (m *Migrator) pretend(migration Migration, schema *Schema) error {
+	statements := schema.ToSQL()
+
+	for _, table := range schema.Tables {
+		if err := table.err(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintln(m.Output, "--", migration); err != nil {
+		return err
+	}
+
+	for _, stmt := range statements {
+		if _, err := fmt.Fprintln(m.Output, strings.TrimSuffix(stmt, ";")+";"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) ensureTable() error {
+	table := CreateTable(m.TableName, func(t *Blueprint) {
+		t.Bigint("version", &NumericColumnProps{PrimaryKey: true})
+		t.Varchar("name", 255, nil)
+		t.Int("batch", nil)
+		t.Timestamp("applied_at", nil)
+	}, m.Dialect)
+
+	return table.Run(m.DB)
+}
+
+func (m *Migrator) table() string {
+	return dialectOf(m.Dialect).QuoteIdent(m.TableName)
+}
+
+func (m *Migrator) placeholder(n int) string {
+	return dialectOf(m.Dialect).Placeholder(n)
+}
+
+func parseAppliedAt(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case []byte:
+		return time.Parse(time.DateTime, string(v))
+	case string:
+		return time.Parse(time.DateTime, v)
+	case nil:
+		return time.Time{}, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unexpected applied_at value %v", value)
+}
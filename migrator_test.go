@@ -0,0 +1,64 @@
+package gomigrator
+
+import (
+	"testing"
+)
+
+func TestRegisterMigrationsInOrder(t *testing.T) {
+	migrator := NewMigrator(nil, MYSQL)
+
+	err := migrator.Register(
+		Migration{Version: 20240102000000, Name: "create_profiles", Up: func(s *Schema) {}},
+		Migration{Version: 20240101000000, Name: "create_users", Up: func(s *Schema) {}},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrations := migrator.Migrations()
+	expected := "20240101000000_create_users"
+
+	if migrations[0].String() != expected {
+		t.Errorf("Expected: %s, and got %q", expected, migrations[0].String())
+	}
+}
+
+func TestRegisterDuplicateVersion(t *testing.T) {
+	migrator := NewMigrator(nil, MYSQL)
+
+	err := migrator.Register(
+		Migration{Version: 1, Name: "create_users", Up: func(s *Schema) {}},
+		Migration{Version: 1, Name: "create_profiles", Up: func(s *Schema) {}},
+	)
+
+	if err == nil {
+		t.Errorf("Expected duplicate version to be rejected")
+	}
+}
+
+func TestRegisterWithoutUp(t *testing.T) {
+	migrator := NewMigrator(nil, MYSQL)
+
+	if err := migrator.Register(Migration{Version: 1, Name: "create_users"}); err == nil {
+		t.Errorf("Expected migration without Up to be rejected")
+	}
+}
+
+func TestRegisterInvalidBatch(t *testing.T) {
+	migrator := NewMigrator(nil, MYSQL)
+
+	err := migrator.Register(
+		Migration{Version: 2, Name: "create_profiles", Up: func(s *Schema) {}},
+		Migration{Version: 1, Name: "create_users", Up: func(s *Schema) {}},
+		Migration{Version: 3, Name: "create_posts"},
+	)
+
+	if err == nil {
+		t.Fatal("Expected a migration without Up to be rejected")
+	}
+
+	if registered := migrator.Migrations(); len(registered) != 0 {
+		t.Errorf("Expected no migration of the invalid batch to be registered, and got %v", registered)
+	}
+}
@@ -0,0 +1,113 @@
+package gomigrator
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+)
+
+type Schema struct {
+	Dialect SQLDialect
+	Tables  []*Table
+}
+
+func NewSchema(dialect SQLDialect) *Schema {
+	return &Schema{Dialect: dialect}
+}
+
+func (s *Schema) Create(name string, tableColumns func(table *Blueprint)) *Table {
+	table := CreateTable(name, tableColumns, s.Dialect)
+	s.Tables = append(s.Tables, table)
+
+	return table
+}
+
+func (s *Schema) Alter(name string, tableColumns func(table *Blueprint)) *Table {
+	table := AlterTable(name, tableColumns, s.Dialect)
+	s.Tables = append(s.Tables, table)
+
+	return table
+}
+
+func (s *Schema) Drop(name string) *Table {
+	table := DropTable(name, s.Dialect)
+	s.Tables = append(s.Tables, table)
+
+	return table
+}
+
+func (s *Schema) DropIfExists(name string) *Table {
+	table := DropTableIfExists(name, s.Dialect)
+	s.Tables = append(s.Tables, table)
+
+	return table
+}
+
+func (s *Schema) Rename(from string, to string) *Table {
+	table := RenameTable(from, to, s.Dialect)
+	s.Tables = append(s.Tables, table)
+
+	return table
+}
+
+func (s *Schema) ToSQL() []string {
+	statements := []string{}
+	for _, table := range s.Tables {
+		statements = append(statements, table.ToSQL(s.Dialect)...)
+	}
+
+	return statements
+}
+
+// Run executes the tables inside one transaction on dialects with
+// transactional DDL. Otherwise a failing table drops the tables created
+// before it by the schema, other changes of those tables stay applied.
+func (s *Schema) Run(db *sql.DB) error {
+	d := dialectOf(s.Dialect)
+
+	if d.Features().TransactionalDDL {
+		return transaction(db, s.Tables, func(tx *sql.Tx) error {
+			return s.exec(tx)
+		})
+	}
+
+	created := []string{}
+
+	for _, table := range s.Tables {
+		exists := true
+
+		if table.action == createTable {
+			var err error
+
+			if exists, err = tableExists(db, d, table.Name); err != nil {
+				return err
+			}
+		}
+
+		if err := table.Run(db); err != nil {
+			slices.Reverse(created)
+
+			for _, name := range created {
+				err = errors.Join(err, execStatements(d.DropTable(name, true, false), db))
+			}
+
+			return err
+		}
+
+		if !exists {
+			created = append(created, table.Name)
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) exec(db Execer) error {
+	for _, table := range s.Tables {
+		if err := table.exec(db); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package gomigrator
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -17,29 +21,109 @@ type SQLTableProp struct {
 	AutoIncrement bool
 	Unsigned      bool
 	EnumOptions   []string
+	ElementType   SQLDataType
 	Unique        bool
 	PrimaryKey    bool
-	Precision     int
+	// Precision is the number of digits of a decimal, or of the fractional
+	// seconds of a temporal column.
+	Precision          int
+	Scale              int
+	UseCurrentOnUpdate bool
+	// GeneratedAs is the expression computing the column, stored on disk
+	// when Stored is set.
+	GeneratedAs string
+	Stored      bool
+	Identity    bool
+	// Check is a boolean expression every row has to satisfy, like
+	// price >= 0.
+	Check   string
+	Changed bool
+	// jsonFallback stores an array as JSON on dialects without arrays.
+	jsonFallback bool
 }
 
 type ForeignKeyOptions struct {
+	// Name defaults to <table>_<columns>_fkey.
+	Name            string
 	ReferenceTable  string
 	ReferenceColumn string
-	OnDelete        string
-	OnUpdate        string
+	// ReferenceColumns are the referenced columns of a composite foreign key,
+	// in place of ReferenceColumn.
+	ReferenceColumns []string
+	// OnDelete and OnUpdate take one of the referential actions, like
+	// ActionCascade.
+	OnDelete string
+	OnUpdate string
+	// Deferrable checks the foreign key when the transaction commits instead
+	// of after every statement.
+	Deferrable bool
+	// NotValid skips checking the existing rows, see Table.ValidateForeign.
+	NotValid bool
 }
 
+const (
+	ActionCascade    = "CASCADE"
+	ActionSetNull    = "SET NULL"
+	ActionRestrict   = "RESTRICT"
+	ActionNoAction   = "NO ACTION"
+	ActionSetDefault = "SET DEFAULT"
+)
+
+var referentialActions = []string{ActionCascade, ActionSetNull, ActionRestrict, ActionNoAction, ActionSetDefault}
+
 const (
 	POSTGRES SQLDialect = "postgres"
 	MYSQL    SQLDialect = "mysql"
+	SQLITE   SQLDialect = "sqlite3"
+	MSSQL    SQLDialect = "sqlserver"
+)
+
+type ConstraintType string
+
+const (
+	PrimaryKeyConstraint ConstraintType = "PRIMARY KEY"
+	UniqueConstraint     ConstraintType = "UNIQUE"
+	CheckConstraint      ConstraintType = "CHECK"
+	ForeignKeyConstraint ConstraintType = "FOREIGN KEY"
+)
+
+// DroppedConstraint is a constraint an altered table drops.
+type DroppedConstraint struct {
+	Name string
+	Type ConstraintType
+}
+
+type tableAction int
+
+const (
+	createTable tableAction = iota
+	alterTable
+	dropTable
+	renameTable
 )
 
 type Table struct {
 	Name                 string
+	action               tableAction
+	ifExists             bool
+	cascade              bool
+	renameTo             string
 	Blueprint            *Blueprint
 	EnumStatements       []string
 	ForeignKeyStatements []string
 	IndexStatements      []string
+	// TableConstraints are rendered inside the CREATE TABLE statement, after
+	// the columns.
+	TableConstraints     []string
+	indexes              []tableIndex
+	constraints          []*tableConstraint
+	validatedForeignKeys []string
+	droppedConstraints   []DroppedConstraint
+}
+
+type tableIndex struct {
+	columns []string
+	gin     bool
 }
 
 func (mt *Table) ColumnLength() int {
@@ -47,96 +131,627 @@ func (mt *Table) ColumnLength() int {
 }
 
 func (o *SQLTableProp) PrintEnumValues() string {
-	return "'" + strings.Join(o.EnumOptions, "', '") + "'"
+	quoted := make([]string, len(o.EnumOptions))
+	for i, option := range o.EnumOptions {
+		quoted[i] = standardQuoteLiteral(option)
+	}
+
+	return strings.Join(quoted, ", ")
 }
 
 func CreateTable(name string, tableColumns func(table *Blueprint), dialect SQLDialect) *Table {
-	table := &Table{Name: name}
+	return newTable(name, createTable, tableColumns, dialect)
+}
+
+func newTable(name string, action tableAction, tableColumns func(table *Blueprint), dialect SQLDialect) *Table {
+	table := &Table{Name: name, action: action}
 	blueprint := &Blueprint{Columns: []TableColumn{}, Dialect: dialect}
 
 	tableColumns(blueprint)
 	table.Blueprint = blueprint
+	table.EnumStatements = table.enumStatements()
+
+	for _, columns := range blueprint.indexes {
+		table.CreateIndex(columns)
+	}
+
+	for _, foreign := range blueprint.foreignIds {
+		if foreign.constrained {
+			table.ForeignKey(foreign.Name, foreign.foreignKeyOptions())
+		}
+	}
 
 	return table
 }
 
+func indexName(table string, columns []string, suffix string) string {
+	return table + "_" + strings.Join(columns, "_") + suffix
+}
+
+func (t *Table) dialect() Dialect {
+	return dialectOf(t.Blueprint.Dialect)
+}
+
 func (t *Table) CreateIndex(columns []string) {
-	indexName := strings.Join(columns, "_")
-	stmt := "CREATE INDEX " + t.Name + "_" + indexName + "_idx" + " ON " + t.Name + "(" + strings.Join(columns, ", ") + ");"
+	t.indexes = append(t.indexes, tableIndex{columns: columns})
+	t.renderIndexes()
+}
+
+// CreateGinIndex creates a GIN index, which Postgres uses to search inside
+// JSONB and array columns.
+func (t *Table) CreateGinIndex(columns []string) {
+	if !t.dialect().Features().GinIndex {
+		t.Blueprint.fail(fmt.Errorf("dialect %s does not support GIN indexes", t.Blueprint.Dialect))
+		return
+	}
 
-	t.IndexStatements = append(t.IndexStatements, stmt)
+	t.indexes = append(t.indexes, tableIndex{columns: columns, gin: true})
+	t.renderIndexes()
 }
 
-func (t *Table) Run(db *sql.DB) error {
-	stmt := parseTableTemplate(t)
+// renderIndexes fills IndexStatements for the table's dialect, GIN indexes
+// become regular ones on dialects without them.
+func (t *Table) renderIndexes() {
+	d := t.dialect()
+	t.IndexStatements = nil
+
+	for _, index := range t.indexes {
+		suffix, using := "_idx", ""
+
+		if index.gin {
+			suffix = "_gin_idx"
 
-	if len(t.EnumStatements) > 0 {
-		execStatements(t.EnumStatements, db)
+			if d.Features().GinIndex {
+				using = " USING GIN "
+			}
+		}
+
+		stmt := "CREATE INDEX " + d.QuoteIdent(indexName(t.Name, index.columns, suffix)) + " ON " + d.QuoteIdent(t.Name) + using + "(" + quoteIdents(d, index.columns) + ");"
+		t.IndexStatements = append(t.IndexStatements, stmt)
+	}
+}
+
+// Run executes the table statements inside a transaction on dialects with
+// transactional DDL. MySQL commits every DDL statement implicitly, so there a
+// failed foreign key or index drops the table again, unless it already
+// existed before Run was called.
+func (t *Table) Run(db *sql.DB) error {
+	if err := t.err(); err != nil {
+		return err
 	}
 
-	_, err := db.Exec(stmt)
+	d := t.dialect()
 
-	if len(t.ForeignKeyStatements) > 0 {
-		execStatements(t.ForeignKeyStatements, db)
+	if d.Features().TransactionalDDL {
+		return transaction(db, []*Table{t}, func(tx *sql.Tx) error {
+			return t.exec(tx)
+		})
 	}
 
-	if len(t.IndexStatements) > 0 {
-		execStatements(t.IndexStatements, db)
+	if t.action != createTable {
+		return t.exec(db)
 	}
 
+	exists, err := tableExists(db, d, t.Name)
+
 	if err != nil {
 		return err
 	}
 
+	err = t.exec(db)
+
+	if err != nil && !exists {
+		if cleanupErr := execStatements(d.DropTable(t.Name, true, false), db); cleanupErr != nil {
+			return errors.Join(err, cleanupErr)
+		}
+	}
+
+	return err
+}
+
+// ToSQL returns the ordered statements Run would execute for dialect, without
+// touching the database. Columns and foreign key actions the dialect can not
+// declare fail the blueprint, see Blueprint.Err.
+func (t *Table) ToSQL(dialect SQLDialect) []string {
+	if dialect == t.Blueprint.Dialect {
+		statements := t.statements()
+
+		if t.needsRebuild() {
+			statements = append(statements, "-- "+t.Name+" is rebuilt from its current definition to apply the changed columns and constraints")
+		}
+
+		return statements
+	}
+
+	blueprint := *t.Blueprint
+	blueprint.Dialect = dialect
+	blueprint.Columns = slices.Clone(t.Blueprint.Columns)
+
+	for i := range blueprint.Columns {
+		prop := *blueprint.Columns[i].Property
+		blueprint.Columns[i].Property = &prop
+		blueprint.Columns[i].dialect = dialect
+		blueprint.Columns[i].blueprint = &blueprint
+		blueprint.checkColumn(&blueprint.Columns[i])
+	}
+
+	for _, constraint := range t.constraints {
+		constraint.checkActions(&blueprint)
+	}
+
+	defer func() {
+		t.Blueprint.fail(blueprint.err)
+	}()
+
+	table := *t
+	table.Blueprint = &blueprint
+	table.EnumStatements = table.enumStatements()
+	table.renderIndexes()
+	table.renderConstraints()
+
+	return table.ToSQL(dialect)
+}
+
+func (t *Table) exec(db Execer) error {
+	// Rendering the statements reports conflicting definitions as well.
+	statements := t.statements()
+
+	if err := t.err(); err != nil {
+		return err
+	}
+
+	if err := execStatements(statements, db); err != nil {
+		return err
+	}
+
+	if !t.needsRebuild() {
+		return nil
+	}
+
+	rebuilder, ok := t.dialect().(TableRebuilder)
+
+	if !ok {
+		return fmt.Errorf("dialect %s cannot change columns or add constraints to table %s", t.Blueprint.Dialect, t.Name)
+	}
+
+	return rebuilder.RebuildTable(db, t)
+}
+
+// DroppedConstraints returns the constraints dropped by DropPrimary,
+// DropUnique, DropCheck and DropForeign.
+func (t *Table) DroppedConstraints() []DroppedConstraint {
+	return slices.Clone(t.droppedConstraints)
+}
+
+func (t *Table) err() error {
+	if err := t.Blueprint.Err(); err != nil {
+		return fmt.Errorf("table %s: %w", t.Name, err)
+	}
+
 	return nil
 }
 
+// needsRebuild reports whether the alterations include changes the dialect
+// cannot apply with ALTER TABLE.
+func (t *Table) needsRebuild() bool {
+	if t.action != alterTable {
+		return false
+	}
+
+	features := t.dialect().Features()
+
+	if len(t.TableConstraints)+len(t.droppedConstraints) > 0 && !features.AlterConstraint {
+		return true
+	}
+
+	return !features.AlterColumn && slices.ContainsFunc(t.Blueprint.Columns, func(column TableColumn) bool {
+		return column.Property.Changed
+	})
+}
+
+func (t *Table) statements() []string {
+	var stmts []string
+
+	switch t.action {
+	case dropTable:
+		return t.dropStatements()
+	case renameTable:
+		return t.renameStatements()
+	case alterTable:
+		stmts = parseAlterTemplate(t)
+	default:
+		stmts = []string{parseTableTemplate(t)}
+	}
+
+	statements := append(slices.Clone(t.EnumStatements), stmts...)
+	statements = append(statements, t.ForeignKeyStatements...)
+
+	return append(statements, t.IndexStatements...)
+}
+
 func (t *Table) CreateEnum(name string, options []string) string {
-	return "DROP TYPE IF EXISTS " + name + "; CREATE TYPE " + name + " AS ENUM('" + strings.Join(options, "', '") + "');"
+	return postgresCreateEnum(postgresDialect{}.QuoteIdent(name), options)
 }
 
 func (t *Table) ForeignKey(column string, options *ForeignKeyOptions) {
-	stmt := "ALTER TABLE " + t.Name + " ADD FOREIGN KEY (" + column + ") REFERENCES " + options.ReferenceTable + "(" + options.ReferenceColumn + ")"
+	t.CompositeForeignKey([]string{column}, options)
+}
+
+// CompositeForeignKey declares a foreign key over columns, referencing as many
+// columns of options.ReferenceTable.
+func (t *Table) CompositeForeignKey(columns []string, options *ForeignKeyOptions) {
+	features := t.dialect().Features()
+	constraint := &tableConstraint{
+		constraintType:   ForeignKeyConstraint,
+		name:             options.Name,
+		columns:          columns,
+		referenceTable:   options.ReferenceTable,
+		referenceColumns: options.ReferenceColumns,
+		deferrable:       options.Deferrable,
+		notValid:         options.NotValid,
+	}
+
+	if constraint.name == "" {
+		constraint.name = indexName(t.Name, columns, "_fkey")
+	}
 
-	if options.OnDelete != "" {
-		stmt += " ON DELETE " + options.OnDelete
+	if len(constraint.referenceColumns) == 0 {
+		constraint.referenceColumns = []string{options.ReferenceColumn}
 	}
 
-	if options.OnUpdate != "" {
-		stmt += " ON UPDATE " + options.OnUpdate
+	if len(constraint.referenceColumns) != len(columns) {
+		t.Blueprint.fail(fmt.Errorf("foreign key %s: %d columns reference %d columns", constraint.name, len(columns), len(constraint.referenceColumns)))
 	}
 
-	stmt += ";"
+	constraint.onDelete = strings.ToUpper(options.OnDelete)
+	constraint.onUpdate = strings.ToUpper(options.OnUpdate)
+	constraint.checkActions(t.Blueprint)
+
+	if options.Deferrable && !features.DeferrableConstraints {
+		t.Blueprint.fail(fmt.Errorf("foreign key %s: dialect %s does not support deferrable constraints", constraint.name, t.Blueprint.Dialect))
+	}
+
+	if options.NotValid && !features.ConstraintValidation {
+		t.Blueprint.fail(fmt.Errorf("foreign key %s: dialect %s does not support NOT VALID", constraint.name, t.Blueprint.Dialect))
+	}
+
+	t.addConstraint(constraint)
+}
+
+// ValidateForeign checks the existing rows against a foreign key added with
+// NotValid.
+func (t *Table) ValidateForeign(name string) {
+	if !t.dialect().Features().ConstraintValidation {
+		t.Blueprint.fail(fmt.Errorf("foreign key %s: dialect %s does not support VALIDATE CONSTRAINT", name, t.Blueprint.Dialect))
+	}
+
+	t.validatedForeignKeys = append(t.validatedForeignKeys, name)
+	t.renderConstraints()
+}
+
+func (t *Table) DropForeign(name string) {
+	t.droppedConstraints = append(t.droppedConstraints, DroppedConstraint{name, ForeignKeyConstraint})
+}
+
+// PrimaryKey declares a primary key over columns, named <table>_pkey.
+func (t *Table) PrimaryKey(columns ...string) {
+	t.addConstraint(&tableConstraint{constraintType: PrimaryKeyConstraint, name: t.Name + "_pkey", columns: columns})
+}
 
-	t.ForeignKeyStatements = append(t.ForeignKeyStatements, stmt)
+// DropPrimary drops the primary key declared by PrimaryKey.
+func (t *Table) DropPrimary() {
+	t.droppedConstraints = append(t.droppedConstraints, DroppedConstraint{t.Name + "_pkey", PrimaryKeyConstraint})
 }
 
+// UniqueKey is a named unique constraint, its Postgres options can be set
+// until the table runs.
+type UniqueKey struct {
+	table      *Table
+	constraint *tableConstraint
+}
+
+// Unique declares a unique constraint named name over columns.
+func (t *Table) Unique(name string, columns ...string) *UniqueKey {
+	constraint := &tableConstraint{constraintType: UniqueConstraint, name: name, columns: columns}
+	t.addConstraint(constraint)
+
+	return &UniqueKey{table: t, constraint: constraint}
+}
+
+func (t *Table) DropUnique(name string) {
+	t.droppedConstraints = append(t.droppedConstraints, DroppedConstraint{name, UniqueConstraint})
+}
+
+// NullsNotDistinct treats NULL values as equal, so only one row may have a
+// NULL in the constrained columns. Only Postgres 15+ supports it.
+func (u *UniqueKey) NullsNotDistinct() *UniqueKey {
+	u.constraint.nullsNotDistinct = true
+
+	return u.update()
+}
+
+// Include stores additional columns in the index of the constraint, for
+// index-only scans. Only Postgres 11+ supports it.
+func (u *UniqueKey) Include(columns ...string) *UniqueKey {
+	u.constraint.include = append(u.constraint.include, columns...)
+
+	return u.update()
+}
+
+func (u *UniqueKey) update() *UniqueKey {
+	t := u.table
+
+	if !t.dialect().Features().UniqueOptions {
+		t.Blueprint.fail(fmt.Errorf("constraint %s: dialect %s does not support NULLS NOT DISTINCT or INCLUDE", u.constraint.name, t.Blueprint.Dialect))
+	}
+
+	t.renderConstraints()
+
+	return u
+}
+
+// Check declares a constraint named name that every row has to satisfy, like
+// start_at < end_at.
+func (t *Table) Check(name string, expr string) {
+	t.addConstraint(&tableConstraint{constraintType: CheckConstraint, name: name, expr: expr})
+}
+
+func (t *Table) DropCheck(name string) {
+	t.droppedConstraints = append(t.droppedConstraints, DroppedConstraint{name, CheckConstraint})
+}
+
+// tableConstraint is a declared constraint, rendered for the dialect the
+// table's statements are generated for.
+type tableConstraint struct {
+	constraintType ConstraintType
+	name           string
+	columns        []string
+	// expr is the condition of a check constraint.
+	expr             string
+	include          []string
+	nullsNotDistinct bool
+	referenceTable   string
+	referenceColumns []string
+	onDelete         string
+	onUpdate         string
+	deferrable       bool
+	notValid         bool
+}
+
+// checkActions fails b when the dialect does not accept the referential
+// actions of a foreign key.
+func (c *tableConstraint) checkActions(b *Blueprint) {
+	accepted := b.features().ReferentialActions
+
+	for _, action := range []struct {
+		clause string
+		value  string
+	}{{"ON DELETE", c.onDelete}, {"ON UPDATE", c.onUpdate}} {
+		switch {
+		case action.value == "":
+		case !slices.Contains(referentialActions, action.value):
+			b.fail(fmt.Errorf("foreign key %s: invalid %s action %q", c.name, action.clause, action.value))
+		case !slices.Contains(accepted, action.value):
+			b.fail(fmt.Errorf("foreign key %s: dialect %s does not support %s %s", c.name, b.Dialect, action.clause, action.value))
+		}
+	}
+}
+
+// render leaves out the options the dialect does not support, declaring them
+// already failed for the table's own dialect.
+func (c *tableConstraint) render(d Dialect) string {
+	features := d.Features()
+	stmt := "CONSTRAINT " + d.QuoteIdent(c.name) + " " + string(c.constraintType)
+
+	switch c.constraintType {
+	case CheckConstraint:
+		return stmt + " (" + c.expr + ")"
+	case UniqueConstraint:
+		if c.nullsNotDistinct && features.UniqueOptions {
+			stmt += " NULLS NOT DISTINCT"
+		}
+
+		stmt += " (" + quoteIdents(d, c.columns) + ")"
+
+		if len(c.include) > 0 && features.UniqueOptions {
+			stmt += " INCLUDE (" + quoteIdents(d, c.include) + ")"
+		}
+
+		return stmt
+	case ForeignKeyConstraint:
+		stmt += " (" + quoteIdents(d, c.columns) + ") REFERENCES " + d.QuoteIdent(c.referenceTable) + "(" + quoteIdents(d, c.referenceColumns) + ")"
+
+		if c.onDelete != "" {
+			stmt += " ON DELETE " + c.onDelete
+		}
+
+		if c.onUpdate != "" {
+			stmt += " ON UPDATE " + c.onUpdate
+		}
+
+		if c.deferrable && features.DeferrableConstraints {
+			stmt += " DEFERRABLE INITIALLY DEFERRED"
+		}
+
+		if c.notValid && features.ConstraintValidation {
+			stmt += " NOT VALID"
+		}
+
+		return stmt
+	}
+
+	return stmt + " (" + quoteIdents(d, c.columns) + ")"
+}
+
+func (t *Table) addConstraint(constraint *tableConstraint) {
+	t.constraints = append(t.constraints, constraint)
+	t.renderConstraints()
+}
+
+// renderConstraints fills TableConstraints and ForeignKeyStatements for the
+// table's dialect. Dialects that cannot add a foreign key to an existing table
+// declare it in the table definition.
+func (t *Table) renderConstraints() {
+	d := t.dialect()
+	features := d.Features()
+	t.TableConstraints = nil
+	t.ForeignKeyStatements = nil
+
+	for _, constraint := range t.constraints {
+		if constraint.constraintType == ForeignKeyConstraint && features.AlterConstraint {
+			t.ForeignKeyStatements = append(t.ForeignKeyStatements, alterTablePrefix(d, t.Name)+"ADD "+constraint.render(d)+";")
+			continue
+		}
+
+		t.TableConstraints = append(t.TableConstraints, constraint.render(d))
+	}
+
+	if !features.ConstraintValidation {
+		return
+	}
+
+	for _, name := range t.validatedForeignKeys {
+		t.ForeignKeyStatements = append(t.ForeignKeyStatements, alterTablePrefix(d, t.Name)+"VALIDATE CONSTRAINT "+d.QuoteIdent(name)+";")
+	}
+}
+
+func primaryKeyConstraint(d Dialect, table string, columns []string) string {
+	return (&tableConstraint{constraintType: PrimaryKeyConstraint, name: table + "_pkey", columns: columns}).render(d)
+}
+
+func (t *Table) enumStatements() []string {
+	d := t.dialect()
+	statements := []string{}
+
+	for i := range t.Blueprint.Columns {
+		statements = append(statements, d.TypeStatements(t.Name, &t.Blueprint.Columns[i])...)
+	}
+
+	return statements
+}
+
+func (t *Table) columnDefinition(column TableColumn) string {
+	return t.dialect().ColumnDefinition(t.Name, &column)
+}
+
+// parseTableTemplate turns the primary key of several columns into one
+// composite primary key, as a table can only have a single one.
 func parseTableTemplate(t *Table) string {
-	stmt := "CREATE TABLE IF NOT EXISTS"
-	stmt += " " + t.Name + "("
-	for i, column := range t.Blueprint.Columns {
-		if t.Blueprint.Dialect == POSTGRES && column.Property.Type == ENUM {
-			enumType := t.Name + "_" + column.Name + "_type"
-			t.EnumStatements = append(t.EnumStatements, t.CreateEnum(enumType, column.Property.EnumOptions))
-			column.Property.Type = SQLDataType(t.Name + "_" + column.Name + "_type")
+	definitions := []string{}
+	primaryKey := []string{}
+	primaryKeys := 0
+
+	for _, column := range t.Blueprint.Columns {
+		if column.Property.AutoIncrement {
+			primaryKeys++
+		} else if column.Property.PrimaryKey {
+			primaryKey = append(primaryKey, column.Name)
+		}
+	}
+
+	if len(primaryKey) > 0 {
+		primaryKeys++
+	}
+
+	for _, constraint := range t.constraints {
+		if constraint.constraintType == PrimaryKeyConstraint {
+			primaryKeys++
+		}
+	}
+
+	if primaryKeys > 1 {
+		t.Blueprint.fail(errors.New("more than one primary key declared"))
+	}
+
+	for _, column := range t.Blueprint.Columns {
+		if len(primaryKey) > 1 && column.Property.PrimaryKey {
+			property := *column.Property
+			property.PrimaryKey = false
+			column.Property = &property
 		}
 
-		stmt += column.ParseColumn() + IfNe(i, t.ColumnLength(), ",")
+		definitions = append(definitions, t.columnDefinition(column))
 	}
 
-	stmt = stmt + ")"
+	if len(primaryKey) > 1 {
+		definitions = append(definitions, primaryKeyConstraint(t.dialect(), t.Name, primaryKey))
+	}
+
+	return t.dialect().CreateTable(t.Name, append(definitions, t.TableConstraints...))
+}
 
-	return stmt
+// Execer runs statements, on a *sql.DB or inside a *sql.Tx.
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
 }
 
-func execStatements(statements []string, db *sql.DB) error {
+func execStatements(statements []string, db Execer) error {
 	for _, stmt := range statements {
 		_, err := db.Exec(stmt)
 
 		if err != nil {
-			return err
+			return fmt.Errorf("%w: %s", err, stmt)
 		}
 	}
 
 	return nil
 }
+
+// transaction runs fn inside a transaction on a single connection, which the
+// dialect prepares beforehand when one of the tables is rebuilt.
+func transaction(db *sql.DB, tables []*Table, fn func(tx *sql.Tx) error) (err error) {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	for _, table := range tables {
+		rebuilder, ok := table.dialect().(TableRebuilder)
+
+		if !ok || !table.needsRebuild() {
+			continue
+		}
+
+		restore, prepareErr := rebuilder.PrepareRebuild(conn)
+
+		if prepareErr != nil {
+			return prepareErr
+		}
+
+		defer func() {
+			err = errors.Join(err, restore())
+		}()
+
+		break
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func tableExists(db *sql.DB, dialect Dialect, name string) (bool, error) {
+	var count int
+
+	err := db.QueryRow(dialect.TableExistsQuery(), name).Scan(&count)
+
+	return count > 0, err
+}
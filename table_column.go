@@ -3,7 +3,6 @@ package gomigrator
 import (
 	"fmt"
 	"slices"
-	"strings"
 )
 
 const (
@@ -25,12 +24,24 @@ const (
 	ENUM             SQLDataType = "enum"
 	DATETIME         SQLDataType = "datetime"
 	TIMESTAMP        SQLDataType = "timestamp"
+	TIMESTAMPTZ      SQLDataType = "timestamptz"
+	TIME             SQLDataType = "time"
+	INTERVAL         SQLDataType = "interval"
 	UUID             SQLDataType = "uuid"
+	DECIMAL          SQLDataType = "decimal"
+	JSON             SQLDataType = "json"
+	JSONB            SQLDataType = "jsonb"
+	BINARY           SQLDataType = "binary"
+	VARBINARY        SQLDataType = "varbinary"
+	BLOB             SQLDataType = "blob"
+	ARRAY            SQLDataType = "array"
 )
 
 type TableColumn struct {
-	Name     string
-	Property *SQLTableProp
+	Name      string
+	Property  *SQLTableProp
+	dialect   SQLDialect
+	blueprint *Blueprint
 }
 
 type TextColumnProps struct {
@@ -39,6 +50,10 @@ type TextColumnProps struct {
 	Default    interface{}
 	PrimaryKey bool
 	Size       int
+	// Precision is the number of fractional second digits of Time,
+	// DateTime, Timestamp, TimestampTz and Interval columns.
+	Precision int
+	Check     string
 }
 
 type NumericColumnProps struct {
@@ -50,6 +65,7 @@ type NumericColumnProps struct {
 	AutoIncrement bool
 	Precision     int
 	Size          int
+	Check         string
 }
 
 type EnumColumnProps struct {
@@ -57,9 +73,37 @@ type EnumColumnProps struct {
 	Nullable bool
 }
 
+type JSONColumnProps struct {
+	Default  interface{}
+	Nullable bool
+	Check    string
+}
+
+type BinaryColumnProps struct {
+	Unique     bool
+	Nullable   bool
+	Default    interface{}
+	PrimaryKey bool
+	// Fixed pads Binary columns to their length instead of storing up to it.
+	Fixed bool
+	// Size is the largest payload in bytes a Blob has to hold.
+	Size  int
+	Check string
+}
+
+type ArrayColumnProps struct {
+	Default  interface{}
+	Nullable bool
+	// JSONFallback stores the array as JSON on dialects without arrays,
+	// instead of failing.
+	JSONFallback bool
+	Check        string
+}
+
 type UUIDColumnProps struct {
 	PrimaryKey bool
 	Unique     bool
+	Nullable   bool
 }
 
 func (c *TableColumn) ParseColumn() string {
@@ -68,7 +112,52 @@ func (c *TableColumn) ParseColumn() string {
 		Property: c.Property,
 	}
 
-	return columnParser(col)
+	// Columns built outside a Blueprint render with the MySQL syntax the
+	// parser always used.
+	dialect, err := LookupDialect(c.dialect)
+
+	if err != nil {
+		dialect = mysqlDialect{}
+	}
+
+	return dialect.ColumnDefinition("", col)
+}
+
+// Change marks the column as a modification of an existing column when used
+// inside AlterTable.
+func (c *TableColumn) Change() *TableColumn {
+	c.Property.Changed = true
+
+	return c
+}
+
+// UseCurrentOnUpdate sets the column to the current timestamp whenever the
+// row is updated. Only MySQL supports it, other dialects ignore the flag.
+func (c *TableColumn) UseCurrentOnUpdate() *TableColumn {
+	c.Property.UseCurrentOnUpdate = true
+
+	return c
+}
+
+// GeneratedAs computes the column from expr. Virtual columns are calculated
+// when read, Postgres only supports stored ones.
+func (c *TableColumn) GeneratedAs(expr string, stored bool) *TableColumn {
+	c.Property.GeneratedAs = expr
+	c.Property.Stored = stored
+
+	if c.blueprint != nil {
+		c.blueprint.checkColumn(c)
+	}
+
+	return c
+}
+
+// Identity makes Postgres use an identity column for Increment and
+// BigIncrement instead of serial. Other dialects ignore the flag.
+func (c *TableColumn) Identity() *TableColumn {
+	c.Property.Identity = true
+
+	return c
 }
 
 func IsNumericColumn(t SQLDataType) bool {
@@ -84,6 +173,7 @@ func IsNumericColumn(t SQLDataType) bool {
 		SERIAL,
 		BIGSERIAL,
 		DOUBLE_PRECISION,
+		DECIMAL,
 	}
 
 	return slices.Index(types, t) >= 0
@@ -101,56 +191,45 @@ func IsTextColumn(t SQLDataType) bool {
 	return slices.Index(types, t) >= 0
 }
 
-func columnParser(col *TableColumn) string {
-	stmt := col.Name + " " + string(col.Property.Type)
+func columnType(prop *SQLTableProp) string {
+	stmt := string(prop.Type)
 
-	if size := col.Property.Size; size > 0 {
-		if precision := col.Property.Precision; precision > 0 {
+	switch prop.Type {
+	case DECIMAL:
+		return fmt.Sprintf("%s(%d, %d)", stmt, prop.Precision, prop.Scale)
+	case TIME, DATETIME, TIMESTAMP, TIMESTAMPTZ, INTERVAL:
+		return temporalType(stmt, prop.Precision)
+	}
+
+	if size := prop.Size; size > 0 {
+		if precision := prop.Precision; precision > 0 {
 			stmt += fmt.Sprintf("(%d, %d)", size, precision)
 		} else {
 			stmt += fmt.Sprintf("(%d)", size)
 		}
 	}
 
-	if col.Property.Type == ENUM {
-		stmt += "(" + col.Property.PrintEnumValues() + ")"
-	}
-
-	if col.Property.AutoIncrement {
-		stmt += " AUTO_INCREMENT PRIMARY KEY"
+	if prop.Type == ENUM {
+		stmt += "(" + prop.PrintEnumValues() + ")"
 	}
 
-	if col.Property.Unsigned {
-		stmt += " UNSIGNED"
-	}
-
-	if col.Property.Unique {
-		stmt += " UNIQUE"
-	}
+	return stmt
+}
 
-	if col.Property.PrimaryKey {
-		stmt += " PRIMARY KEY"
+func temporalType(name string, precision int) string {
+	if precision > 0 {
+		return fmt.Sprintf("%s(%d)", name, precision)
 	}
 
-	if col.Property.Nullable {
-		stmt += " NULL"
-	}
+	return name
+}
 
-	if col.Property.Default != nil {
-		switch col.Property.Default.(type) {
-		case string:
-			if strings.Contains(fmt.Sprintf("%s", col.Property.Default), "()") {
-				stmt += " DEFAULT " + fmt.Sprintf("%v", col.Property.Default)
-			} else {
-				stmt += " DEFAULT " + fmt.Sprintf("'%v'", col.Property.Default)
-			}
-		default:
-			stmt += " DEFAULT " + fmt.Sprintf("%v", col.Property.Default)
-		}
-	}
+// Raw is a column default that is emitted verbatim, like a function call or
+// an expression. Every other default is rendered as an escaped literal.
+type Raw string
 
-	return stmt
-}
+// Null sets a column default to NULL, a nil Default means no default.
+const Null Raw = "NULL"
 
 func fillProps(t *SQLTableProp, props interface{}) error {
 	switch p := props.(type) {
@@ -159,6 +238,13 @@ func fillProps(t *SQLTableProp, props interface{}) error {
 		t.Default = p.Default
 		t.PrimaryKey = p.PrimaryKey
 		t.Nullable = p.Nullable
+		t.Check = p.Check
+
+		// Text columns have no precision, only their length.
+		if slices.Contains([]SQLDataType{TIME, DATETIME, TIMESTAMP, TIMESTAMPTZ, INTERVAL}, t.Type) {
+			t.Precision = p.Precision
+		}
+
 		return nil
 	case *NumericColumnProps:
 		t.Unique = p.Unique
@@ -169,14 +255,34 @@ func fillProps(t *SQLTableProp, props interface{}) error {
 		t.Unsigned = p.Unsigned
 		t.Precision = p.Precision
 		t.Size = p.Size
+		t.Check = p.Check
 		return nil
 	case *EnumColumnProps:
 		t.Default = p.Default
 		t.Nullable = p.Nullable
 		return nil
+	case *JSONColumnProps:
+		t.Default = p.Default
+		t.Nullable = p.Nullable
+		t.Check = p.Check
+		return nil
+	case *ArrayColumnProps:
+		t.Default = p.Default
+		t.Nullable = p.Nullable
+		t.Check = p.Check
+		return nil
+	case *BinaryColumnProps:
+		t.Unique = p.Unique
+		t.Default = p.Default
+		t.PrimaryKey = p.PrimaryKey
+		t.Nullable = p.Nullable
+		t.Size = p.Size
+		t.Check = p.Check
+		return nil
 	case *UUIDColumnProps:
 		t.PrimaryKey = p.PrimaryKey
 		t.Unique = p.Unique
+		t.Nullable = p.Nullable
 	}
 
 	return fmt.Errorf("invalid type %v", props)
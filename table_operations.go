@@ -0,0 +1,32 @@
+package gomigrator
+
+func DropTable(name string, dialect SQLDialect) *Table {
+	return &Table{Name: name, action: dropTable, Blueprint: &Blueprint{Dialect: dialect}}
+}
+
+func DropTableIfExists(name string, dialect SQLDialect) *Table {
+	table := DropTable(name, dialect)
+	table.ifExists = true
+
+	return table
+}
+
+func RenameTable(from string, to string, dialect SQLDialect) *Table {
+	return &Table{Name: from, action: renameTable, renameTo: to, Blueprint: &Blueprint{Dialect: dialect}}
+}
+
+// Cascade drops the objects depending on the table as well. Only Postgres
+// supports it, other dialects ignore the flag.
+func (t *Table) Cascade() *Table {
+	t.cascade = true
+
+	return t
+}
+
+func (t *Table) dropStatements() []string {
+	return t.dialect().DropTable(t.Name, t.ifExists, t.cascade)
+}
+
+func (t *Table) renameStatements() []string {
+	return t.dialect().RenameTable(t.Name, t.renameTo)
+}
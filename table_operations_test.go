@@ -0,0 +1,46 @@
+package gomigrator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDropTable(t *testing.T) {
+	stmt := DropTable("users", MYSQL).statements()[0]
+	expected := "DROP TABLE `users`"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestDropTableIfExistsCascade(t *testing.T) {
+	statements := DropTableIfExists("user_roles", POSTGRES).Cascade().statements()
+	expected := `DROP TABLE IF EXISTS "user_roles" CASCADE`
+
+	if statements[0] != expected {
+		t.Errorf("Expected: %s, and got %q", expected, statements[0])
+	}
+
+	if !strings.Contains(statements[1], `LIKE 'user\_roles\_%\_type'`) {
+		t.Errorf("Expected enum types of user_roles to be dropped, and got %q", statements[1])
+	}
+}
+
+func TestDropTableIfExistsIgnoresCascadeOnMysql(t *testing.T) {
+	statements := DropTableIfExists("users", MYSQL).Cascade().statements()
+	expected := "DROP TABLE IF EXISTS `users`"
+
+	if len(statements) != 1 || statements[0] != expected {
+		t.Errorf("Expected: %s, and got %q", expected, statements)
+	}
+}
+
+func TestRenameTable(t *testing.T) {
+	stmt := RenameTable("users", "members", MYSQL).statements()[0]
+	expected := "ALTER TABLE `users` RENAME TO `members`"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
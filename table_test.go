@@ -1,6 +1,8 @@
 package gomigrator
 
 import (
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -11,7 +13,7 @@ func TestVarcharColumn(t *testing.T) {
 
 	stmt := table.Blueprint.Columns[0].ParseColumn()
 
-	expected := "first_name varchar(50)"
+	expected := "`first_name` varchar(50) NOT NULL"
 
 	if stmt != expected {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -25,7 +27,7 @@ func TestIntColumnWithAutoIncrement(t *testing.T) {
 
 	stmt := table.Blueprint.Columns[0].ParseColumn()
 
-	expected := "ID int AUTO_INCREMENT PRIMARY KEY"
+	expected := "`ID` int AUTO_INCREMENT PRIMARY KEY"
 
 	if stmt != expected {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -38,7 +40,7 @@ func TestUnsignedInt(t *testing.T) {
 	}, MYSQL)
 	stmt := table.Blueprint.Columns[0].ParseColumn()
 
-	expected := "ID int UNSIGNED"
+	expected := "`ID` int UNSIGNED NOT NULL"
 
 	if stmt != expected {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -65,21 +67,21 @@ func TestTextsColumn(t *testing.T) {
 	samples := []sample{
 		{
 			Type:     CHAR,
-			Expected: "name char(10)",
+			Expected: "`name` char(10) NOT NULL",
 			Size:     10,
 		},
 		{
 			Type:     VARCHAR,
-			Expected: "name varchar(50)",
+			Expected: "`name` varchar(50) NOT NULL",
 			Size:     50,
 		},
 		{
 			Type:     DATE,
-			Expected: "name date",
+			Expected: "`name` date NOT NULL",
 		},
 		{
 			Type:     DATETIME,
-			Expected: "name datetime",
+			Expected: "`name` datetime NOT NULL",
 		},
 	}
 
@@ -112,23 +114,23 @@ func TestIntegersColumn(t *testing.T) {
 	samples := []sample{
 		{
 			Type:     INT,
-			Expected: "ID int",
+			Expected: "`ID` int NOT NULL",
 		},
 		{
 			Type:     TINYINT,
-			Expected: "ID tinyint",
+			Expected: "`ID` tinyint NOT NULL",
 		},
 		{
 			Type:     MEDIUMINT,
-			Expected: "ID mediumint",
+			Expected: "`ID` mediumint NOT NULL",
 		},
 		{
 			Type:     BIGINT,
-			Expected: "ID bigint",
+			Expected: "`ID` bigint NOT NULL",
 		},
 		{
 			Type:     BOOL,
-			Expected: "ID bool",
+			Expected: "`ID` bool NOT NULL",
 		},
 	}
 
@@ -163,7 +165,7 @@ func TestEnumWithDefault(t *testing.T) {
 
 	stmt := table.Blueprint.Columns[0].ParseColumn()
 
-	expected := "role enum('admin', 'employee', 'supervisor') DEFAULT 'admin'"
+	expected := "`role` enum('admin', 'employee', 'supervisor') NOT NULL DEFAULT 'admin'"
 
 	if stmt != expected {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -189,7 +191,7 @@ func TestFloatWithNullable(t *testing.T) {
 
 	stmt := columns.Blueprint.Columns[0].ParseColumn()
 
-	expected := "mark float(53) NULL"
+	expected := "`mark` float(53) NULL"
 
 	if stmt != expected {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -203,7 +205,7 @@ func TestDoubleWithNullable(t *testing.T) {
 
 	stmt := table.Blueprint.Columns[0].ParseColumn()
 
-	expected := "mark double(53) NULL"
+	expected := "`mark` double(53) NULL"
 
 	if stmt != expected {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -216,7 +218,7 @@ func TestIncrement(t *testing.T) {
 	}, MYSQL)
 
 	stmt := table.Blueprint.Columns[0].ParseColumn()
-	expected := "ID int AUTO_INCREMENT PRIMARY KEY"
+	expected := "`ID` int AUTO_INCREMENT PRIMARY KEY"
 
 	if condition := stmt != expected; condition {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -227,7 +229,7 @@ func TestIncrement(t *testing.T) {
 	}, POSTGRES)
 
 	stmt = table.Blueprint.Columns[0].ParseColumn()
-	expected = "ID serial PRIMARY KEY"
+	expected = `"ID" serial PRIMARY KEY`
 
 	if condition := stmt != expected; condition {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -240,7 +242,7 @@ func TestBigIncrement(t *testing.T) {
 	}, MYSQL)
 
 	stmt := table.Blueprint.Columns[0].ParseColumn()
-	expected := "ID bigint AUTO_INCREMENT PRIMARY KEY"
+	expected := "`ID` bigint AUTO_INCREMENT PRIMARY KEY"
 
 	if condition := stmt != expected; condition {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -251,7 +253,7 @@ func TestBigIncrement(t *testing.T) {
 	}, POSTGRES)
 
 	stmt = table.Blueprint.Columns[0].ParseColumn()
-	expected = "ID bigserial PRIMARY KEY"
+	expected = `"ID" bigserial PRIMARY KEY`
 
 	if condition := stmt != expected; condition {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -264,7 +266,7 @@ func TestUuid(t *testing.T) {
 	}, POSTGRES)
 
 	stmt := table.Blueprint.Columns[0].ParseColumn()
-	expected := "ID uuid PRIMARY KEY DEFAULT gen_random_uuid()"
+	expected := `"ID" uuid PRIMARY KEY DEFAULT gen_random_uuid()`
 	if condition := stmt != expected; condition {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
 	}
@@ -274,7 +276,7 @@ func TestUuid(t *testing.T) {
 	}, MYSQL)
 
 	stmt = table.Blueprint.Columns[0].ParseColumn()
-	expected = "ID varchar(36) PRIMARY KEY DEFAULT uuid()"
+	expected = "`ID` varchar(36) PRIMARY KEY DEFAULT uuid()"
 
 	if condition := stmt != expected; condition {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
@@ -291,7 +293,7 @@ func TestCreateTableParsing(t *testing.T) {
 	}, MYSQL)
 
 	stmt := parseTableTemplate(table)
-	expected := "CREATE TABLE IF NOT EXISTS users(ID int AUTO_INCREMENT PRIMARY KEY,first_name varchar(50),last_name varchar(50) NULL,dob date,bio text)"
+	expected := "CREATE TABLE IF NOT EXISTS `users`(`ID` int AUTO_INCREMENT PRIMARY KEY,`first_name` varchar(50) NOT NULL,`last_name` varchar(50) NULL,`dob` date NOT NULL,`bio` text NOT NULL)"
 
 	if stmt != expected {
 		t.Errorf("Expected: %s, but got %q", expected, stmt)
@@ -307,7 +309,7 @@ func TestCreateIndex(t *testing.T) {
 	}, MYSQL)
 	table.CreateIndex([]string{"first_name", "last_name"})
 
-	expected := "CREATE INDEX users_first_name_last_name_idx ON users(first_name, last_name);"
+	expected := "CREATE INDEX `users_first_name_last_name_idx` ON `users`(`first_name`, `last_name`);"
 
 	if table.IndexStatements[0] != expected {
 		t.Errorf("Expected: %s, and got %q", expected, table.IndexStatements[0])
@@ -324,9 +326,677 @@ func TestForeignKey(t *testing.T) {
 	tableProfile.ForeignKey("user_id", &ForeignKeyOptions{ReferenceTable: "users", ReferenceColumn: "id"})
 
 	stmt := tableProfile.ForeignKeyStatements[0]
-	expected := "ALTER TABLE profiles ADD FOREIGN KEY (user_id) REFERENCES users(id);"
+	expected := "ALTER TABLE `profiles` ADD CONSTRAINT `profiles_user_id_fkey` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`);"
 
 	if stmt != expected {
 		t.Errorf("Expected: %s, and got %q", expected, stmt)
 	}
 }
+
+func TestToSQL(t *testing.T) {
+	table := CreateTable("users", func(t *Blueprint) {
+		t.Increment("id")
+		t.Enum("role", []string{"admin", "member"}, nil)
+		t.Uuid("team_id", nil)
+	}, POSTGRES)
+	table.ForeignKey("team_id", &ForeignKeyOptions{ReferenceTable: "teams", ReferenceColumn: "id"})
+	table.CreateIndex([]string{"role"})
+
+	statements := table.ToSQL(POSTGRES)
+	expected := []string{
+		`DO $$ BEGIN CREATE TYPE "users_role_type" AS ENUM('admin', 'member'); EXCEPTION WHEN duplicate_object THEN NULL; END $$;`,
+		`CREATE TABLE IF NOT EXISTS "users"("id" serial PRIMARY KEY,"role" "users_role_type" NOT NULL,"team_id" uuid NOT NULL DEFAULT gen_random_uuid())`,
+		`ALTER TABLE "users" ADD CONSTRAINT "users_team_id_fkey" FOREIGN KEY ("team_id") REFERENCES "teams"("id");`,
+		`CREATE INDEX "users_role_idx" ON "users"("role");`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+
+	if again := table.ToSQL(POSTGRES); !slices.Equal(again, statements) {
+		t.Errorf("Expected ToSQL to be repeatable, and got %q", again)
+	}
+}
+
+func TestCreateTableParsingSqlite(t *testing.T) {
+	table := CreateTable("users", func(t *Blueprint) {
+		t.Increment("id")
+		t.Varchar("name", 50, nil)
+		t.Enum("role", []string{"admin", "member"}, &EnumColumnProps{Default: "member"})
+		t.Boolean("active", &NumericColumnProps{Default: 1})
+		t.DateTime("born_at", &TextColumnProps{Nullable: true})
+	}, SQLITE)
+	table.ForeignKey("id", &ForeignKeyOptions{ReferenceTable: "accounts", ReferenceColumn: "id", OnDelete: "CASCADE"})
+
+	stmt := parseTableTemplate(table)
+	expected := `CREATE TABLE IF NOT EXISTS "users"("id" integer PRIMARY KEY AUTOINCREMENT,"name" text NOT NULL,` +
+		`"role" text CHECK ("role" IN ('admin', 'member')) NOT NULL DEFAULT 'member',"active" integer NOT NULL DEFAULT 1,"born_at" datetime NULL,` +
+		`CONSTRAINT "users_id_fkey" FOREIGN KEY ("id") REFERENCES "accounts"("id") ON DELETE CASCADE)`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got %q", expected, stmt)
+	}
+}
+
+func TestUuidSqlite(t *testing.T) {
+	table := CreateTable("users", func(table *Blueprint) {
+		table.Uuid("id", &UUIDColumnProps{PrimaryKey: true})
+	}, SQLITE)
+
+	stmt := table.Blueprint.Columns[0].ParseColumn()
+	expected := `"id" text PRIMARY KEY DEFAULT ` + sqliteUUID
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestSplitDefinitions(t *testing.T) {
+	definitions := splitDefinitions("id integer, name text DEFAULT 'a, b', price real CHECK (price IN (1, 2)), PRIMARY KEY (id)")
+	expected := []string{"id integer", "name text DEFAULT 'a, b'", "price real CHECK (price IN (1, 2))", "PRIMARY KEY (id)"}
+
+	if !slices.Equal(definitions, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, definitions)
+	}
+}
+
+func TestCreateTableParsingMssql(t *testing.T) {
+	table := CreateTable("users", func(t *Blueprint) {
+		t.Increment("id")
+		t.Uuid("code", nil)
+		t.Varchar("name", 50, nil)
+		t.Enum("role", []string{"admin", "member"}, &EnumColumnProps{Default: "member"})
+		t.Boolean("active", nil)
+		t.DateTime("born_at", &TextColumnProps{Nullable: true})
+	}, MSSQL)
+
+	stmt := parseTableTemplate(table)
+	expected := "IF OBJECT_ID(N'[users]', N'U') IS NULL CREATE TABLE [users]([id] int IDENTITY(1,1) PRIMARY KEY," +
+		"[code] uniqueidentifier NOT NULL DEFAULT NEWID(),[name] nvarchar(50) NOT NULL," +
+		"[role] nvarchar(255) CHECK ([role] IN ('admin', 'member')) NOT NULL DEFAULT 'member',[active] bit NOT NULL,[born_at] datetime2 NULL)"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, but got %q", expected, stmt)
+	}
+}
+
+func TestAlterTableMssql(t *testing.T) {
+	table := AlterTable("users", func(t *Blueprint) {
+		t.RenameColumn("name", "full_name")
+		t.Varchar("nickname", 30, &TextColumnProps{Nullable: true})
+		t.Text("bio", &TextColumnProps{Nullable: true}).Change()
+	}, MSSQL)
+
+	statements := table.statements()
+	expected := []string{
+		"EXEC sp_rename N'[users].[name]', N'full_name', N'COLUMN'",
+		"ALTER TABLE [users] ADD [nickname] nvarchar(30) NULL",
+		"ALTER TABLE [users] ALTER COLUMN [bio] nvarchar(max) NULL",
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestReservedWordsAndLiteralsAreQuoted(t *testing.T) {
+	table := CreateTable("order", func(t *Blueprint) {
+		t.Varchar("user", 50, &TextColumnProps{Default: `it's C:\`})
+		t.Enum("group", []string{"o'neil", "admin"}, nil)
+		t.Timestamp("placed_at", &TextColumnProps{Default: Raw("CURRENT_TIMESTAMP")})
+	}, MYSQL)
+
+	stmt := parseTableTemplate(table)
+	expected := "CREATE TABLE IF NOT EXISTS `order`(`user` varchar(50) NOT NULL DEFAULT 'it''s C:\\\\'," +
+		"`group` enum('o''neil', 'admin') NOT NULL,`placed_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP)"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	table = CreateTable("order", func(t *Blueprint) {
+		t.Enum("group", []string{"o'neil"}, nil)
+	}, POSTGRES)
+
+	expected = `DO $$ BEGIN CREATE TYPE "order_group_type" AS ENUM('o''neil'); EXCEPTION WHEN duplicate_object THEN NULL; END $$;`
+
+	if table.EnumStatements[0] != expected {
+		t.Errorf("Expected: %s, and got %q", expected, table.EnumStatements[0])
+	}
+
+	table = CreateTable("o'rder.items", func(t *Blueprint) {
+		t.Int("quantity", nil)
+	}, MSSQL)
+
+	stmt = parseTableTemplate(table)
+	expected = "IF OBJECT_ID(N'[o''rder.items]', N'U') IS NULL CREATE TABLE [o'rder.items]([quantity] int NOT NULL)"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	table := CreateTable("orders", func(t *Blueprint) {
+		t.Decimal("total", 10, 2, &NumericColumnProps{Default: 0})
+	}, POSTGRES)
+
+	stmt := table.Blueprint.Columns[0].ParseColumn()
+	expected := `"total" numeric(10, 2) NOT NULL DEFAULT 0`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	table = CreateTable("orders", func(t *Blueprint) {
+		t.Decimal("total", 10, 2, &NumericColumnProps{Nullable: true})
+	}, MYSQL)
+
+	stmt = table.Blueprint.Columns[0].ParseColumn()
+	expected = "`total` decimal(10, 2) NULL"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestDecimalScaleExceedsPrecision(t *testing.T) {
+	table := CreateTable("orders", func(t *Blueprint) {
+		t.Decimal("total", 2, 4, nil)
+	}, MYSQL)
+
+	if table.Blueprint.Err() == nil {
+		t.Fatal("Expected a scale above the precision to be rejected")
+	}
+
+	if err := table.Run(nil); err == nil || !strings.Contains(err.Error(), "invalid decimal precision 2 and scale 4") {
+		t.Errorf("Expected Run to return the blueprint error, and got %v", err)
+	}
+}
+
+func TestJsonb(t *testing.T) {
+	table := CreateTable("events", func(t *Blueprint) {
+		t.Jsonb("payload", &JSONColumnProps{Default: map[string]interface{}{"tags": []string{"new"}}})
+		t.Json("meta", &JSONColumnProps{Nullable: true})
+	}, POSTGRES)
+	table.CreateGinIndex([]string{"payload"})
+
+	statements := table.statements()
+	expected := []string{
+		`CREATE TABLE IF NOT EXISTS "events"("payload" jsonb NOT NULL DEFAULT '{"tags":["new"]}',"meta" json NULL)`,
+		`CREATE INDEX "events_payload_gin_idx" ON "events" USING GIN ("payload");`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestJsonbMysql(t *testing.T) {
+	table := CreateTable("events", func(t *Blueprint) {
+		t.Jsonb("payload", &JSONColumnProps{Default: []int{1, 2}})
+	}, MYSQL)
+	table.CreateGinIndex([]string{"payload"})
+
+	stmt := table.Blueprint.Columns[0].ParseColumn()
+	expected := "`payload` json NOT NULL DEFAULT ('[1,2]')"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	if table.Blueprint.Err() == nil {
+		t.Error("Expected a GIN index to be rejected on MySQL")
+	}
+}
+
+func TestBinaryAndBlob(t *testing.T) {
+	build := func(t *Blueprint) {
+		t.Binary("hash", 32, &BinaryColumnProps{Fixed: true, Default: []byte{0xde, 0xad}})
+		t.Binary("token", 64, nil)
+		t.Blob("thumbnail", &BinaryColumnProps{Size: 60000, Nullable: true})
+		t.Blob("payload", &BinaryColumnProps{Size: 1 << 30, Default: []byte{0x01}})
+	}
+
+	expected := "CREATE TABLE IF NOT EXISTS `files`(`hash` binary(32) NOT NULL DEFAULT X'DEAD',`token` varbinary(64) NOT NULL," +
+		"`thumbnail` blob NULL,`payload` longblob NOT NULL DEFAULT (X'01'))"
+
+	if stmt := parseTableTemplate(CreateTable("files", build, MYSQL)); stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	expected = `CREATE TABLE IF NOT EXISTS "files"("hash" bytea NOT NULL DEFAULT '\xdead',"token" bytea NOT NULL,` +
+		`"thumbnail" bytea NULL,"payload" bytea NOT NULL DEFAULT '\x01')`
+
+	if stmt := parseTableTemplate(CreateTable("files", build, POSTGRES)); stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestBinaryWithoutLength(t *testing.T) {
+	table := CreateTable("files", func(t *Blueprint) {
+		t.Binary("token", 0, nil)
+	}, MYSQL)
+
+	if err := table.Run(nil); err == nil || !strings.Contains(err.Error(), "invalid binary length 0") {
+		t.Errorf("Expected a binary column without length to be rejected, and got %v", err)
+	}
+}
+
+func TestTemporalColumns(t *testing.T) {
+	build := func(t *Blueprint) {
+		t.DateTime("starts_at", &TextColumnProps{Precision: 3})
+		t.TimestampTz("sent_at", &TextColumnProps{Nullable: true})
+		t.Time("opens_at", nil)
+		t.Timestamp("updated_at", &TextColumnProps{Precision: 6, Default: Raw("CURRENT_TIMESTAMP(6)")}).UseCurrentOnUpdate()
+	}
+
+	expected := `CREATE TABLE IF NOT EXISTS "events"("starts_at" timestamp(3) NOT NULL,"sent_at" timestamptz NULL,` +
+		`"opens_at" time NOT NULL,"updated_at" timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6))`
+
+	if stmt := parseTableTemplate(CreateTable("events", build, POSTGRES)); stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	expected = "CREATE TABLE IF NOT EXISTS `events`(`starts_at` datetime(3) NOT NULL,`sent_at` timestamp NULL,`opens_at` time NOT NULL," +
+		"`updated_at` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6))"
+
+	if stmt := parseTableTemplate(CreateTable("events", build, MYSQL)); stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestPrecisionIgnoredOnText(t *testing.T) {
+	table := CreateTable("events", func(t *Blueprint) {
+		t.Varchar("name", 20, &TextColumnProps{Precision: 3})
+	}, MYSQL)
+
+	stmt := table.Blueprint.Columns[0].ParseColumn()
+	expected := "`name` varchar(20) NOT NULL"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	table := CreateTable("plans", func(t *Blueprint) {
+		t.Interval("duration", &TextColumnProps{Default: Raw("interval '1 day'")})
+	}, POSTGRES)
+
+	stmt := table.Blueprint.Columns[0].ParseColumn()
+	expected := `"duration" interval NOT NULL DEFAULT interval '1 day'`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	table = CreateTable("plans", func(t *Blueprint) {
+		t.Interval("duration", nil)
+	}, MYSQL)
+
+	if table.Blueprint.Err() == nil {
+		t.Error("Expected an interval column to be rejected on MySQL")
+	}
+}
+
+func TestTimestampsAndSoftDeletes(t *testing.T) {
+	table := CreateTable("posts", func(t *Blueprint) {
+		t.Timestamps()
+		t.SoftDeletes()
+	}, MYSQL)
+
+	statements := table.statements()
+	expected := []string{
+		"CREATE TABLE IF NOT EXISTS `posts`(`created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP," +
+			"`updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,`deleted_at` timestamp NULL)",
+		"CREATE INDEX `posts_deleted_at_idx` ON `posts`(`deleted_at`);",
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+}
+
+func TestArray(t *testing.T) {
+	table := CreateTable("posts", func(t *Blueprint) {
+		t.Array("tags", TEXT, &ArrayColumnProps{Default: []string{"new", `say "hi"`}})
+		t.Array("scores", INT, &ArrayColumnProps{Default: []int{}})
+		t.Array("published_at", DATETIME, &ArrayColumnProps{Nullable: true})
+	}, POSTGRES)
+
+	stmt := parseTableTemplate(table)
+	expected := `CREATE TABLE IF NOT EXISTS "posts"("tags" text[] NOT NULL DEFAULT '{"new","say \"hi\""}',` +
+		`"scores" int[] NOT NULL DEFAULT '{}',"published_at" timestamp[] NULL)`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestArrayMysql(t *testing.T) {
+	table := CreateTable("posts", func(t *Blueprint) {
+		t.Array("tags", TEXT, nil)
+	}, MYSQL)
+
+	if table.Blueprint.Err() == nil {
+		t.Error("Expected an array column to be rejected on MySQL")
+	}
+
+	table = CreateTable("posts", func(t *Blueprint) {
+		t.Array("tags", TEXT, &ArrayColumnProps{JSONFallback: true, Default: []string{"new"}})
+	}, MYSQL)
+
+	stmt := table.Blueprint.Columns[0].ParseColumn()
+	expected := "`tags` json NOT NULL DEFAULT ('[\"new\"]')"
+
+	if table.Blueprint.Err() != nil || stmt != expected {
+		t.Errorf("Expected: %s, and got %q %v", expected, stmt, table.Blueprint.Err())
+	}
+}
+
+func TestGeneratedColumn(t *testing.T) {
+	build := func(t *Blueprint) {
+		t.Varchar("first_name", 50, nil)
+		t.Varchar("full_name", 101, nil).GeneratedAs("first_name || ' ' || last_name", true)
+		t.Int("name_length", &NumericColumnProps{Nullable: true}).GeneratedAs("length(first_name)", false)
+	}
+
+	tests := []struct {
+		dialect  SQLDialect
+		expected string
+	}{
+		{MYSQL, "CREATE TABLE IF NOT EXISTS `users`(`first_name` varchar(50) NOT NULL," +
+			"`full_name` varchar(101) AS (first_name || ' ' || last_name) STORED NOT NULL," +
+			"`name_length` int AS (length(first_name)) VIRTUAL NULL)"},
+		{MSSQL, "IF OBJECT_ID(N'[users]', N'U') IS NULL CREATE TABLE [users]([first_name] nvarchar(50) NOT NULL," +
+			"[full_name] AS (first_name || ' ' || last_name) PERSISTED NOT NULL,[name_length] AS (length(first_name)))"},
+	}
+
+	for _, test := range tests {
+		if stmt := parseTableTemplate(CreateTable("users", build, test.dialect)); stmt != test.expected {
+			t.Errorf("Expected: %s, and got %q", test.expected, stmt)
+		}
+	}
+
+	table := CreateTable("users", func(t *Blueprint) {
+		t.Varchar("email", 100, nil)
+		t.Varchar("email_key", 100, &TextColumnProps{Unique: true, Check: "email_key <> ''"}).GeneratedAs("lower(email)", true)
+	}, MSSQL)
+
+	stmt := table.Blueprint.Columns[1].ParseColumn()
+	expected := "[email_key] AS (lower(email)) PERSISTED NOT NULL UNIQUE CHECK (email_key <> '')"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	table = CreateTable("users", func(t *Blueprint) {
+		t.Varchar("first_name", 50, nil)
+		t.Varchar("full_name", 101, nil).GeneratedAs("first_name || ' ' || last_name", true)
+	}, POSTGRES)
+
+	stmt = parseTableTemplate(table)
+	expected = `CREATE TABLE IF NOT EXISTS "users"("first_name" varchar(50) NOT NULL,` +
+		`"full_name" varchar(101) GENERATED ALWAYS AS (first_name || ' ' || last_name) STORED NOT NULL)`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	if err := CreateTable("users", build, POSTGRES).err(); err == nil {
+		t.Error("Expected a virtual generated column to fail on Postgres")
+	}
+
+	if stmt := CreateTable("users", build, MYSQL).ToSQL(POSTGRES)[0]; strings.Contains(stmt, "VIRTUAL") {
+		t.Errorf("Expected Postgres to store every generated column, and got %q", stmt)
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	table := CreateTable("users", func(t *Blueprint) {
+		t.BigIncrement("id").Identity()
+	}, POSTGRES)
+
+	stmt := table.Blueprint.Columns[0].ParseColumn()
+	expected := `"id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestCompositePrimaryKey(t *testing.T) {
+	table := CreateTable("user_roles", func(t *Blueprint) {
+		t.Bigint("user_id", &NumericColumnProps{PrimaryKey: true})
+		t.Bigint("role_id", &NumericColumnProps{PrimaryKey: true})
+	}, MYSQL)
+
+	stmt := parseTableTemplate(table)
+	expected := "CREATE TABLE IF NOT EXISTS `user_roles`(`user_id` bigint NOT NULL,`role_id` bigint NOT NULL," +
+		"CONSTRAINT `user_roles_pkey` PRIMARY KEY (`user_id`, `role_id`))"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	table = CreateTable("user_roles", func(t *Blueprint) {
+		t.Bigint("user_id", nil)
+		t.Bigint("role_id", nil)
+	}, POSTGRES)
+	table.PrimaryKey("user_id", "role_id")
+
+	stmt = parseTableTemplate(table)
+	expected = `CREATE TABLE IF NOT EXISTS "user_roles"("user_id" bigint NOT NULL,"role_id" bigint NOT NULL,` +
+		`CONSTRAINT "user_roles_pkey" PRIMARY KEY ("user_id", "role_id"))`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestUniqueConstraint(t *testing.T) {
+	table := CreateTable("memberships", func(t *Blueprint) {
+		t.Bigint("team_id", nil)
+		t.Bigint("user_id", &NumericColumnProps{Nullable: true})
+		t.Varchar("role", 20, nil)
+	}, POSTGRES)
+	table.Unique("memberships_team_user_unique", "team_id", "user_id").NullsNotDistinct().Include("role")
+
+	stmt := parseTableTemplate(table)
+	expected := `CREATE TABLE IF NOT EXISTS "memberships"("team_id" bigint NOT NULL,"user_id" bigint NULL,"role" varchar(20) NOT NULL,` +
+		`CONSTRAINT "memberships_team_user_unique" UNIQUE NULLS NOT DISTINCT ("team_id", "user_id") INCLUDE ("role"))`
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+
+	table = CreateTable("memberships", func(t *Blueprint) {
+		t.Bigint("team_id", nil)
+		t.Bigint("user_id", nil)
+	}, MYSQL)
+	table.Unique("memberships_team_user_unique", "team_id", "user_id")
+
+	if err := table.err(); err != nil {
+		t.Fatal(err)
+	}
+
+	table.Unique("memberships_user_unique", "user_id").NullsNotDistinct()
+
+	if err := table.err(); err == nil {
+		t.Error("Expected NULLS NOT DISTINCT to fail on MySQL")
+	}
+}
+
+func TestCheckConstraint(t *testing.T) {
+	table := CreateTable("bookings", func(t *Blueprint) {
+		t.Decimal("price", 10, 2, &NumericColumnProps{Check: "price >= 0"})
+		t.Timestamp("start_at", nil)
+		t.Timestamp("end_at", nil)
+	}, MYSQL)
+	table.Check("bookings_period_check", "start_at < end_at")
+
+	stmt := parseTableTemplate(table)
+	expected := "CREATE TABLE IF NOT EXISTS `bookings`(`price` decimal(10, 2) NOT NULL CHECK (price >= 0)," +
+		"`start_at` timestamp NOT NULL,`end_at` timestamp NOT NULL,CONSTRAINT `bookings_period_check` CHECK (start_at < end_at))"
+
+	if stmt != expected {
+		t.Errorf("Expected: %s, and got %q", expected, stmt)
+	}
+}
+
+func TestCompositeForeignKey(t *testing.T) {
+	table := CreateTable("memberships", func(t *Blueprint) {
+		t.Bigint("team_id", nil)
+		t.Bigint("user_id", nil)
+	}, POSTGRES)
+	table.CompositeForeignKey([]string{"team_id", "user_id"}, &ForeignKeyOptions{
+		ReferenceTable:   "team_users",
+		ReferenceColumns: []string{"team_id", "user_id"},
+		OnDelete:         "cascade",
+		OnUpdate:         ActionNoAction,
+		Deferrable:       true,
+		NotValid:         true,
+	})
+	table.ValidateForeign("memberships_team_id_user_id_fkey")
+
+	expected := []string{
+		`ALTER TABLE "memberships" ADD CONSTRAINT "memberships_team_id_user_id_fkey" FOREIGN KEY ("team_id", "user_id") ` +
+			`REFERENCES "team_users"("team_id", "user_id") ON DELETE CASCADE ON UPDATE NO ACTION DEFERRABLE INITIALLY DEFERRED NOT VALID;`,
+		`ALTER TABLE "memberships" VALIDATE CONSTRAINT "memberships_team_id_user_id_fkey";`,
+	}
+
+	if !slices.Equal(table.ForeignKeyStatements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, table.ForeignKeyStatements)
+	}
+}
+
+func TestForeignKeyValidation(t *testing.T) {
+	options := []*ForeignKeyOptions{
+		{ReferenceTable: "users", ReferenceColumn: "id", OnDelete: "DELETE"},
+		{ReferenceTable: "users", ReferenceColumn: "id", OnDelete: ActionSetDefault},
+		{ReferenceTable: "users", ReferenceColumns: []string{"id", "email"}},
+		{ReferenceTable: "users", ReferenceColumn: "id", Deferrable: true},
+		{ReferenceTable: "users", ReferenceColumn: "id", NotValid: true},
+	}
+
+	for _, option := range options {
+		table := CreateTable("profiles", func(t *Blueprint) {
+			t.Bigint("user_id", nil)
+		}, MYSQL)
+		table.ForeignKey("user_id", option)
+
+		if err := table.err(); err == nil {
+			t.Errorf("Expected %+v to fail on MySQL", *option)
+		}
+	}
+}
+
+func TestReferentialActionsPerDialect(t *testing.T) {
+	table := CreateTable("posts", func(t *Blueprint) {
+		t.ForeignId("user_id").Constrained().RestrictOnDelete()
+	}, MSSQL)
+
+	if err := table.err(); err == nil || !strings.Contains(err.Error(), "does not support ON DELETE RESTRICT") {
+		t.Errorf("Expected RESTRICT to be rejected on SQL Server, and got %v", err)
+	}
+
+	table = CreateTable("posts", func(t *Blueprint) {
+		t.Bigint("user_id", nil)
+	}, POSTGRES)
+	table.ForeignKey("user_id", &ForeignKeyOptions{ReferenceTable: "users", ReferenceColumn: "id", OnUpdate: ActionSetDefault})
+
+	if statements := table.ToSQL(MYSQL); table.Blueprint.Err() == nil {
+		t.Errorf("Expected SET DEFAULT to fail on MySQL, and got %q", statements)
+	}
+}
+
+func TestToSQLOtherDialect(t *testing.T) {
+	table := CreateTable("posts", func(t *Blueprint) {
+		t.Increment("id")
+		t.Varchar("title", 100, nil)
+		t.Int("user_id", nil)
+	}, POSTGRES)
+	table.ForeignKey("user_id", &ForeignKeyOptions{ReferenceTable: "users", ReferenceColumn: "id", Deferrable: true})
+	table.Unique("posts_title_key", "title").NullsNotDistinct()
+	table.CreateIndex([]string{"title"})
+
+	statements := table.ToSQL(MYSQL)
+	expected := []string{
+		"CREATE TABLE IF NOT EXISTS `posts`(`id` int AUTO_INCREMENT PRIMARY KEY,`title` varchar(100) NOT NULL,`user_id` int NOT NULL," +
+			"CONSTRAINT `posts_title_key` UNIQUE (`title`))",
+		"ALTER TABLE `posts` ADD CONSTRAINT `posts_user_id_fkey` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`);",
+		"CREATE INDEX `posts_title_idx` ON `posts`(`title`);",
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+
+	statements = table.ToSQL(SQLITE)
+	expected = []string{
+		`CREATE TABLE IF NOT EXISTS "posts"("id" integer PRIMARY KEY AUTOINCREMENT,"title" text NOT NULL,"user_id" integer NOT NULL,` +
+			`CONSTRAINT "posts_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") DEFERRABLE INITIALLY DEFERRED,` +
+			`CONSTRAINT "posts_title_key" UNIQUE ("title"))`,
+		`CREATE INDEX "posts_title_idx" ON "posts"("title");`,
+	}
+
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected: %v, and got %q", expected, statements)
+	}
+
+	if stmt := table.ToSQL(POSTGRES)[0]; !strings.Contains(stmt, `CONSTRAINT "posts_title_key" UNIQUE NULLS NOT DISTINCT ("title")`) {
+		t.Errorf("Expected the table's own dialect to keep its statements, and got %q", stmt)
+	}
+}
+
+func TestToSQLRevalidatesColumns(t *testing.T) {
+	for _, dialect := range []SQLDialect{MYSQL, SQLITE, MSSQL} {
+		table := CreateTable("posts", func(t *Blueprint) {
+			t.Array("tags", TEXT, nil)
+		}, POSTGRES)
+
+		if statements := table.ToSQL(dialect); table.Blueprint.Err() == nil {
+			t.Errorf("Expected an array column to fail on %s, and got %q", dialect, statements)
+		}
+
+		table = CreateTable("plans", func(t *Blueprint) {
+			t.Interval("duration", nil)
+		}, POSTGRES)
+
+		if statements := table.ToSQL(dialect); table.Blueprint.Err() == nil {
+			t.Errorf("Expected an interval column to fail on %s, and got %q", dialect, statements)
+		}
+	}
+
+	table := CreateTable("posts", func(t *Blueprint) {
+		t.Array("tags", TEXT, &ArrayColumnProps{JSONFallback: true})
+	}, POSTGRES)
+
+	statements := table.ToSQL(MYSQL)
+	expected := "CREATE TABLE IF NOT EXISTS `posts`(`tags` json NOT NULL)"
+
+	if statements[0] != expected || table.Blueprint.Err() != nil {
+		t.Errorf("Expected: %s, and got %q with %v", expected, statements[0], table.Blueprint.Err())
+	}
+
+	if stmt := table.ToSQL(POSTGRES)[0]; !strings.Contains(stmt, `"tags" text[]`) {
+		t.Errorf("Expected the table's own dialect to keep the array, and got %q", stmt)
+	}
+}
+
+func TestConflictingPrimaryKeys(t *testing.T) {
+	for _, dialect := range []SQLDialect{POSTGRES, MYSQL, SQLITE, MSSQL} {
+		table := CreateTable("user_roles", func(t *Blueprint) {
+			t.Increment("id")
+			t.Int("user_id", nil)
+			t.Int("role_id", nil)
+		}, dialect)
+		table.PrimaryKey("user_id", "role_id")
+
+		if statements := table.ToSQL(dialect); table.err() == nil {
+			t.Errorf("Expected two primary keys to fail on %s, and got %q", dialect, statements)
+		}
+	}
+}
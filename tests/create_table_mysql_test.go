@@ -25,7 +25,7 @@ func TestCreateTableMysql(t *testing.T) {
 
 	defer db.Close()
 
-	_, _ = db.Exec("DROP TABLE IF EXISTS items")
+	_ = gomigrator.DropTableIfExists("items", gomigrator.MYSQL).Run(db)
 
 	err = table.Run(db)
 
@@ -49,7 +49,7 @@ func TestCreateTableWithUUIDMysql(t *testing.T) {
 
 	defer db.Close()
 
-	_, _ = db.Exec("DROP TABLE IF EXISTS items")
+	_ = gomigrator.DropTableIfExists("items", gomigrator.MYSQL).Run(db)
 
 	err = table.Run(db)
 
@@ -117,3 +117,32 @@ func TestCreateTableWithIndexMysql(t *testing.T) {
 	}
 
 }
+
+func TestCreateTableCleanupOnFailureMysql(t *testing.T) {
+	db, err := gomigrator.NewConnection("mysql", "root:mariadb@tcp(localhost:3306)/go-migrator")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	defer db.Close()
+
+	_ = gomigrator.DropTableIfExists("broken_items", gomigrator.MYSQL).Run(db)
+
+	table := gomigrator.CreateTable("broken_items", func(t *gomigrator.Blueprint) {
+		t.Increment("id")
+		t.Varchar("name", 50, nil)
+	}, gomigrator.MYSQL)
+	table.CreateIndex([]string{"missing_column"})
+
+	if err = table.Run(db); err == nil {
+		t.Error("Expected index on a missing column to fail")
+	}
+
+	var count int
+	_ = db.QueryRow("SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'broken_items'").Scan(&count)
+
+	if count != 0 {
+		t.Error("Expected broken_items to be dropped after the failure")
+	}
+}
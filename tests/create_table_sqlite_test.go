@@ -0,0 +1,609 @@
+package tests
+
+import (
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	gomigrator "github.com/suryaherdiyanto/go-migrator"
+)
+
+func sqliteConnection(t *testing.T) *sql.DB {
+	db, err := gomigrator.NewConnection("sqlite3", filepath.Join(t.TempDir(), "go-migrator.db"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return db
+}
+
+func TestCreateTableSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	table := gomigrator.CreateTable("items", func(t *gomigrator.Blueprint) {
+		t.Increment("id")
+		t.Varchar("name", 50, nil)
+		t.Varchar("sku", 50, &gomigrator.TextColumnProps{Nullable: false, Unique: true})
+		t.Float("mark", &gomigrator.NumericColumnProps{Nullable: true})
+		t.Double("price", &gomigrator.NumericColumnProps{Nullable: true})
+		t.Enum("status", []string{"active", "inactive"}, &gomigrator.EnumColumnProps{Default: "inactive"})
+		t.Text("description", &gomigrator.TextColumnProps{Nullable: true})
+		t.Uuid("code", nil)
+	}, gomigrator.SQLITE)
+	table.CreateIndex([]string{"name"})
+
+	if err := table.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec("INSERT INTO items (name, sku) VALUES ('chair', 'CH-1')"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := db.Exec("INSERT INTO items (name, sku, status) VALUES ('desk', 'DE-1', 'sold')"); err == nil {
+		t.Error("Expected the enum check constraint to reject an unknown status")
+	}
+
+	if _, err := db.Exec("INSERT INTO items (sku) VALUES ('TA-1')"); err == nil {
+		t.Error("Expected the name column to be NOT NULL")
+	}
+}
+
+func TestCreateTableWithForeignKeySqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	tableUser := gomigrator.CreateTable("users", func(t *gomigrator.Blueprint) {
+		t.Uuid("id", &gomigrator.UUIDColumnProps{PrimaryKey: true})
+		t.Varchar("first_name", 50, nil)
+	}, gomigrator.SQLITE)
+
+	tableProfile := gomigrator.CreateTable("profiles", func(t *gomigrator.Blueprint) {
+		t.Uuid("id", &gomigrator.UUIDColumnProps{PrimaryKey: true})
+		t.Uuid("user_id", nil)
+	}, gomigrator.SQLITE)
+	tableProfile.ForeignKey("user_id", &gomigrator.ForeignKeyOptions{ReferenceTable: "users", ReferenceColumn: "id", OnDelete: "CASCADE"})
+
+	if err := tableUser.Run(db); err != nil {
+		t.Error(err)
+	}
+
+	if err := tableProfile.Run(db); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAlterTableChangeColumnSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	table := gomigrator.CreateTable("items", func(t *gomigrator.Blueprint) {
+		t.Increment("id")
+		t.Varchar("name", 50, nil)
+		t.Int("stock", nil)
+	}, gomigrator.SQLITE)
+	table.CreateIndex([]string{"name"})
+
+	if err := table.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec("INSERT INTO items (name, stock) VALUES ('chair', 3)"); err != nil {
+		t.Fatal(err)
+	}
+
+	alter := gomigrator.AlterTable("items", func(t *gomigrator.Blueprint) {
+		t.Int("stock", &gomigrator.NumericColumnProps{Default: 0}).Change()
+		t.Varchar("color", 20, &gomigrator.TextColumnProps{Nullable: true})
+		t.RenameColumn("name", "title")
+	}, gomigrator.SQLITE)
+
+	if err := alter.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var title string
+	var stock int
+
+	if err := db.QueryRow("SELECT title, stock FROM items").Scan(&title, &stock); err != nil {
+		t.Fatal(err)
+	}
+
+	if title != "chair" || stock != 3 {
+		t.Errorf("Expected the rows to survive the rebuild, and got %s %d", title, stock)
+	}
+
+	var indexes int
+	_ = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND tbl_name = 'items' AND name = 'items_name_idx'").Scan(&indexes)
+
+	if indexes != 1 {
+		t.Error("Expected the index to be recreated after the rebuild")
+	}
+}
+
+func TestTimestampsAndSoftDeletesSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	table := gomigrator.CreateTable("posts", func(t *gomigrator.Blueprint) {
+		t.Increment("id")
+		t.Varchar("title", 50, nil)
+		t.Timestamps()
+		t.SoftDeletes()
+	}, gomigrator.SQLITE)
+
+	if err := table.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec("INSERT INTO posts (title) VALUES ('hello')"); err != nil {
+		t.Fatal(err)
+	}
+
+	var createdAt string
+
+	if err := db.QueryRow("SELECT created_at FROM posts WHERE deleted_at IS NULL").Scan(&createdAt); err != nil || createdAt == "" {
+		t.Errorf("Expected created_at to default to the current time, and got %q %v", createdAt, err)
+	}
+
+	alter := gomigrator.AlterTable("posts", func(t *gomigrator.Blueprint) {
+		t.DropTimestamps()
+		t.DropSoftDeletes()
+	}, gomigrator.SQLITE)
+
+	if err := alter.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var columns int
+	_ = db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('posts')").Scan(&columns)
+
+	if columns != 2 {
+		t.Errorf("Expected only id and title to remain, and got %d columns", columns)
+	}
+}
+
+func TestDropPrimarySqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	table := gomigrator.CreateTable("user_roles", func(t *gomigrator.Blueprint) {
+		t.Int("user_id", nil)
+		t.Int("role_id", nil)
+	}, gomigrator.SQLITE)
+	table.PrimaryKey("user_id", "role_id")
+
+	if err := table.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	insert := "INSERT INTO user_roles (user_id, role_id) VALUES (1, 2)"
+
+	if _, err := db.Exec(insert); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(insert); err == nil {
+		t.Fatal("Expected the composite primary key to reject a duplicate")
+	}
+
+	alter := gomigrator.AlterTable("user_roles", func(t *gomigrator.Blueprint) {}, gomigrator.SQLITE)
+	alter.DropPrimary()
+
+	if err := alter.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(insert); err != nil {
+		t.Errorf("Expected the duplicate to be accepted without the primary key, and got %v", err)
+	}
+}
+
+func TestUniqueConstraintSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	table := gomigrator.CreateTable("memberships", func(t *gomigrator.Blueprint) {
+		t.Int("team_id", nil)
+		t.Int("user_id", nil)
+	}, gomigrator.SQLITE)
+	table.Unique("memberships_team_user_unique", "team_id", "user_id")
+
+	if err := table.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	insert := "INSERT INTO memberships (team_id, user_id) VALUES (1, 2)"
+
+	if _, err := db.Exec(insert); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(insert); err == nil {
+		t.Fatal("Expected the unique constraint to reject a duplicate")
+	}
+
+	alter := gomigrator.AlterTable("memberships", func(t *gomigrator.Blueprint) {}, gomigrator.SQLITE)
+	alter.DropUnique("memberships_team_user_unique")
+
+	if err := alter.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(insert); err != nil {
+		t.Errorf("Expected the duplicate to be accepted without the unique constraint, and got %v", err)
+	}
+}
+
+func TestCheckConstraintSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	table := gomigrator.CreateTable("bookings", func(t *gomigrator.Blueprint) {
+		t.Int("price", &gomigrator.NumericColumnProps{Check: "price >= 0"})
+		t.Int("start_at", nil)
+		t.Int("end_at", nil)
+	}, gomigrator.SQLITE)
+	table.Check("bookings_period_check", "start_at < end_at")
+
+	if err := table.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec("INSERT INTO bookings (price, start_at, end_at) VALUES (-1, 1, 2)"); err == nil {
+		t.Error("Expected the column check to reject a negative price")
+	}
+
+	insert := "INSERT INTO bookings (price, start_at, end_at) VALUES (1, 2, 1)"
+
+	if _, err := db.Exec(insert); err == nil {
+		t.Fatal("Expected the table check to reject an inverted period")
+	}
+
+	alter := gomigrator.AlterTable("bookings", func(t *gomigrator.Blueprint) {}, gomigrator.SQLITE)
+	alter.DropCheck("bookings_period_check")
+
+	if err := alter.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(insert); err != nil {
+		t.Errorf("Expected the inverted period to be accepted without the check, and got %v", err)
+	}
+}
+
+func TestDropForeignSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	teamUsers := gomigrator.CreateTable("team_users", func(t *gomigrator.Blueprint) {
+		t.Int("team_id", &gomigrator.NumericColumnProps{PrimaryKey: true})
+		t.Int("user_id", &gomigrator.NumericColumnProps{PrimaryKey: true})
+	}, gomigrator.SQLITE)
+
+	memberships := gomigrator.CreateTable("memberships", func(t *gomigrator.Blueprint) {
+		t.Int("team_id", nil)
+		t.Int("user_id", nil)
+	}, gomigrator.SQLITE)
+	memberships.CompositeForeignKey([]string{"team_id", "user_id"}, &gomigrator.ForeignKeyOptions{
+		ReferenceTable:   "team_users",
+		ReferenceColumns: []string{"team_id", "user_id"},
+		OnDelete:         gomigrator.ActionCascade,
+		Deferrable:       true,
+	})
+
+	for _, table := range []*gomigrator.Table{teamUsers, memberships} {
+		if err := table.Run(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	alter := gomigrator.AlterTable("memberships", func(t *gomigrator.Blueprint) {}, gomigrator.SQLITE)
+	alter.DropForeign("memberships_team_id_user_id_fkey")
+
+	if err := alter.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+
+	if err := db.QueryRow("SELECT COUNT(*) FROM pragma_foreign_key_list('memberships')").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Errorf("Expected the foreign key to be dropped, and got %d references", count)
+	}
+}
+
+func TestForeignIdSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+	// The pragma holds for a single connection.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		t.Fatal(err)
+	}
+
+	users := gomigrator.CreateTable("users", func(t *gomigrator.Blueprint) {
+		t.BigIncrement("id")
+	}, gomigrator.SQLITE)
+
+	posts := gomigrator.CreateTable("posts", func(t *gomigrator.Blueprint) {
+		t.BigIncrement("id")
+		t.ForeignId("user_id").Constrained().CascadeOnDelete()
+	}, gomigrator.SQLITE)
+
+	for _, table := range []*gomigrator.Table{users, posts} {
+		if err := table.Run(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := db.Exec("INSERT INTO posts (user_id) VALUES (1)"); err == nil {
+		t.Fatal("Expected the foreign key to reject a missing user")
+	}
+
+	for _, stmt := range []string{"INSERT INTO users (id) VALUES (1)", "INSERT INTO posts (user_id) VALUES (1)", "DELETE FROM users"} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var count int
+
+	if err := db.QueryRow("SELECT COUNT(*) FROM posts").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Errorf("Expected the posts to be deleted with their user, and got %d", count)
+	}
+}
+
+func TestRebuildWithForeignKeysSqlite(t *testing.T) {
+	db, err := gomigrator.NewConnection("sqlite3", "file:"+filepath.Join(t.TempDir(), "go-migrator.db")+"?_foreign_keys=on")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	users := gomigrator.CreateTable("users", func(t *gomigrator.Blueprint) {
+		t.BigIncrement("id")
+	}, gomigrator.SQLITE)
+
+	posts := gomigrator.CreateTable("posts", func(t *gomigrator.Blueprint) {
+		t.BigIncrement("id")
+		t.ForeignId("user_id").Constrained().CascadeOnDelete()
+		t.Varchar("title", 50, nil)
+	}, gomigrator.SQLITE)
+
+	for _, table := range []*gomigrator.Table{users, posts} {
+		if err := table.Run(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, stmt := range []string{"INSERT INTO users (id) VALUES (1)", "INSERT INTO posts (user_id, title) VALUES (1, 'Hello')"} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	alter := gomigrator.AlterTable("posts", func(t *gomigrator.Blueprint) {
+		t.Varchar("title", 100, &gomigrator.TextColumnProps{Nullable: true}).Change()
+	}, gomigrator.SQLITE)
+
+	if err := alter.Run(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec("INSERT INTO posts (user_id) VALUES (2)"); err == nil {
+		t.Error("Expected foreign keys to be enforced again after the rebuild")
+	}
+
+	if _, err := db.Exec("INSERT INTO posts (user_id) VALUES (1)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The rebuild checks the existing rows against the added foreign key, the
+	// second post has no user of the same id.
+	alter = gomigrator.AlterTable("posts", func(t *gomigrator.Blueprint) {}, gomigrator.SQLITE)
+	alter.ForeignKey("id", &gomigrator.ForeignKeyOptions{ReferenceTable: "users", ReferenceColumn: "id"})
+
+	if err := alter.Run(db); err == nil {
+		t.Error("Expected the rebuild to fail on rows violating the foreign key")
+	}
+}
+
+func TestRollbackMixedBatchesSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	migration := func(version int64, table string) gomigrator.Migration {
+		return gomigrator.Migration{
+			Version: version,
+			Name:    "create_" + table,
+			Up: func(s *gomigrator.Schema) {
+				s.Create(table, func(t *gomigrator.Blueprint) {
+					t.Increment("id")
+				})
+			},
+			Down: func(s *gomigrator.Schema) {
+				s.Drop(table)
+			},
+		}
+	}
+
+	migrator := gomigrator.NewMigrator(db, gomigrator.SQLITE)
+
+	if err := migrator.Register(migration(1, "tags"), migration(3, "posts")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrator.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A version merged from another branch, applied in a later batch.
+	if err := migrator.Register(migration(2, "users")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrator.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	versions := func() []int64 {
+		applied, err := migrator.Applied()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		versions := []int64{}
+		for _, record := range applied {
+			versions = append(versions, record.Version)
+		}
+
+		return versions
+	}
+
+	if err := migrator.Rollback(0); err != nil {
+		t.Fatal(err)
+	}
+
+	if applied := versions(); !slices.Equal(applied, []int64{1, 3}) {
+		t.Errorf("Expected the last batch to be rolled back, and got %v applied", applied)
+	}
+
+	if err := migrator.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrator.Rollback(2); err != nil {
+		t.Fatal(err)
+	}
+
+	if applied := versions(); !slices.Equal(applied, []int64{1}) {
+		t.Errorf("Expected the two most recently applied migrations to be rolled back, and got %v applied", applied)
+	}
+}
+
+// autocommitSqlite is SQLite without transactional DDL, to run schemas the way
+// MySQL does.
+type autocommitSqlite struct {
+	gomigrator.Dialect
+}
+
+func (autocommitSqlite) Name() gomigrator.SQLDialect {
+	return "autocommit_sqlite"
+}
+
+func (d autocommitSqlite) Features() gomigrator.DialectFeatures {
+	features := d.Dialect.Features()
+	features.TransactionalDDL = false
+
+	return features
+}
+
+var registerAutocommitSqlite sync.Once
+
+func TestSchemaCleanupWithoutTransactionalDDLSqlite(t *testing.T) {
+	registerAutocommitSqlite.Do(func() {
+		sqlite, _ := gomigrator.LookupDialect(gomigrator.SQLITE)
+		gomigrator.RegisterDialect(autocommitSqlite{sqlite})
+	})
+
+	db := sqliteConnection(t)
+
+	if _, err := db.Exec("CREATE TABLE tags(id integer PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := gomigrator.NewSchema("autocommit_sqlite")
+	schema.Create("tags", func(t *gomigrator.Blueprint) {
+		t.Increment("id")
+	})
+	schema.Create("users", func(t *gomigrator.Blueprint) {
+		t.Increment("id")
+	})
+	schema.Create("posts", func(t *gomigrator.Blueprint) {
+		t.Increment("id")
+		t.Varchar("title", 50, nil)
+		t.Varchar("title", 50, nil)
+	})
+
+	if err := schema.Run(db); err == nil {
+		t.Fatal("Expected the duplicate column to fail")
+	}
+
+	tables := []string{}
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+
+		tables = append(tables, name)
+	}
+
+	if expected := []string{"tags"}; !slices.Equal(tables, expected) {
+		t.Errorf("Expected: %v, and got %v", expected, tables)
+	}
+}
+
+func TestMigrateSqlite(t *testing.T) {
+	db := sqliteConnection(t)
+
+	migrator := gomigrator.NewMigrator(db, gomigrator.SQLITE)
+	// A reserved word, every statement on the table has to quote it.
+	migrator.TableName = "order"
+	err := migrator.Register(gomigrator.Migration{
+		Version: 20240101000000,
+		Name:    "create_tags",
+		Up: func(s *gomigrator.Schema) {
+			s.Create("tags", func(t *gomigrator.Blueprint) {
+				t.Increment("id")
+				t.Varchar("name", 50, nil)
+			})
+		},
+		Down: func(s *gomigrator.Schema) {
+			s.Drop("tags")
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = migrator.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := migrator.Applied()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(applied) != 1 || applied[0].AppliedAt.IsZero() {
+		t.Errorf("Expected one applied migration, and got %v", applied)
+	}
+
+	if err = migrator.Rollback(0); err != nil {
+		t.Error(err)
+	}
+}
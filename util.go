@@ -1,8 +1,14 @@
 package gomigrator
 
+import "strings"
+
 func IfNe(a interface{}, b interface{}, c string) string {
 	if a != b {
 		return c
 	}
 	return ""
 }
+
+func escapeLiteral(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}